@@ -0,0 +1,155 @@
+// Package jsonutil normalizes JSON documents and compares decoded JSON
+// values. The provider uses it to decide whether configured params differ
+// from the params the API returns, and thereby whether Terraform shows a
+// diff.
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Normalize returns the canonical form of a JSON document: object keys
+// sorted, no insignificant whitespace, no HTML escaping and numbers in their
+// shortest form.
+func Normalize(document string) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("unexpected data after the JSON value at offset %d", decoder.InputOffset())
+	}
+	value = normalizeNumbers(value)
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))), nil
+}
+
+// normalizeNumbers rewrites the numbers of a value decoded with UseNumber
+// in their shortest form. Integers are kept as they are, so that IDs beyond
+// the precision of floating point numbers are not rounded.
+func normalizeNumbers(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeNumbers(item)
+		}
+	case json.Number:
+		if _, ok := new(big.Int).SetString(value.String(), 10); ok {
+			return value
+		}
+		if number, err := value.Float64(); err == nil {
+			return json.Number(strconv.FormatFloat(number, 'g', -1, 64))
+		}
+	}
+	return value
+}
+
+// Contains recursively compares two unmarshalled JSON values (interface{}).
+// It returns true if `planValue` is logically contained within `stateValue`,
+// meaning all elements in `planValue` exist and match in `stateValue`,
+// but `stateValue` can have additional elements. When `unordered` is set,
+// arrays match if their elements can be paired up in any order.
+func Contains(planValue, stateValue interface{}, unordered bool) bool {
+	// Use reflect.DeepEqual for basic types and nil checks
+	if reflect.DeepEqual(planValue, stateValue) {
+		return true
+	}
+
+	planMap, planIsMap := planValue.(map[string]interface{})
+	stateMap, stateIsMap := stateValue.(map[string]interface{})
+
+	planSlice, planIsSlice := planValue.([]interface{})
+	stateSlice, stateIsSlice := stateValue.([]interface{})
+
+	// Type mismatch (e.g., map vs slice, map vs scalar)
+	if planIsMap != stateIsMap || planIsSlice != stateIsSlice {
+		return false
+	}
+
+	if planIsMap {
+		// Compare maps: ensure all keys in planMap exist in stateMap with matching values
+		for key, planSubValue := range planMap {
+			stateSubValue, ok := stateMap[key]
+			if !ok {
+				return false // Key missing in state
+			}
+			if !Contains(planSubValue, stateSubValue, unordered) {
+				return false // Values differ recursively
+			}
+		}
+		return true // All plan keys/values found and matched in state
+	}
+
+	if planIsSlice {
+		// Compare slices: must have the same length and elements must match recursively in order
+		if len(planSlice) != len(stateSlice) {
+			return false
+		}
+		if unordered {
+			return matchArrays(planSlice, stateSlice)
+		}
+		for i := range planSlice {
+			if !Contains(planSlice[i], stateSlice[i], false) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// For scalars (string, number, bool, nil), DeepEqual should have caught matches.
+	// If we reach here, it means scalars differ.
+	return false
+}
+
+// matchArrays reports whether every element of planSlice can be paired
+// with a distinct element of stateSlice that contains it, in any order. Object
+// elements are thereby compared by their keys rather than their position.
+func matchArrays(planSlice, stateSlice []interface{}) bool {
+	// matched[j] is the index of the plan element paired with stateSlice[j]
+	matched := make([]int, len(stateSlice))
+	for j := range matched {
+		matched[j] = -1
+	}
+
+	// pair finds a state element for planSlice[i], moving previously paired
+	// plan elements to other state elements where necessary
+	var pair func(i int, visited []bool) bool
+	pair = func(i int, visited []bool) bool {
+		for j := range stateSlice {
+			if visited[j] || !Contains(planSlice[i], stateSlice[j], true) {
+				continue
+			}
+			visited[j] = true
+			if matched[j] == -1 || pair(matched[j], visited) {
+				matched[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range planSlice {
+		if !pair(i, make([]bool, len(stateSlice))) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,262 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var documents = []string{
+	`{}`,
+	`[]`,
+	`null`,
+	`true`,
+	`"<a&b>"`,
+	`0`,
+	`-0.0`,
+	`1.0`,
+	`1e3`,
+	`12345678901234567890123`,
+	`1e400`,
+	`{"b": 1, "a": [3, 2, {"y": null, "x": "é"}]}`,
+	`{"a": 1, "a": 2}`,
+	`{"addresses": ["0xb", "0xa"], "threshold": {"value": 1.50, "window": "1h"}}`,
+	`[{"id": 1, "tags": ["x"]}, {"id": 2}, {"id": 1}]`,
+	`{"a":1} {"b":2}`,
+	`{"a":`,
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		`{ "b": 1, "a": 2 }`:         `{"a":2,"b":1}`,
+		`{"url": "https://x?a=1&b"}`: `{"url":"https://x?a=1&b"}`,
+		`[1.0, 1e3, 0.10, -0.0]`:     `[1,1000,0.1,-0]`,
+		`12345678901234567890123`:    `12345678901234567890123`,
+	}
+	for document, want := range tests {
+		got, err := Normalize(document)
+		if err != nil || got != want {
+			t.Errorf("Normalize(%s) = %s, %v, want %s", document, got, err, want)
+		}
+	}
+
+	for _, document := range []string{``, `{"a":`, `{"a":1} {"b":2}`, `[1,]`} {
+		if got, err := Normalize(document); err == nil {
+			t.Errorf("Normalize(%q) = %s, want an error", document, got)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		plan, state string
+		unordered   bool
+		want        bool
+	}{
+		{`{"a":1}`, `{"a":1,"b":2}`, false, true},
+		{`{"a":1,"b":2}`, `{"a":1}`, false, false},
+		{`{"a":{"b":1}}`, `{"a":{"b":1,"c":2}}`, false, true},
+		{`{"a":1}`, `{"a":"1"}`, false, false},
+		{`[1,2]`, `[2,1]`, false, false},
+		{`[1,2]`, `[2,1]`, true, true},
+		{`[1,2]`, `[1,2,3]`, true, false},
+		{`[1,1]`, `[1,2]`, true, false},
+		{`[{"id":1},{"id":2}]`, `[{"id":2,"x":true},{"id":1,"x":false}]`, true, true},
+		{`[{"id":1},{}]`, `[{"id":1}, {"id":2}]`, true, true},
+		{`{}`, `[]`, false, false},
+		{`null`, `{}`, false, false},
+	}
+	for _, test := range tests {
+		if got := Contains(decode(t, test.plan), decode(t, test.state), test.unordered); got != test.want {
+			t.Errorf("Contains(%s, %s, %t) = %t, want %t", test.plan, test.state, test.unordered, got, test.want)
+		}
+	}
+}
+
+// FuzzNormalize checks that Normalize is idempotent, that the normalized
+// document has the same value and that it doesn't depend on the order of
+// object keys.
+func FuzzNormalize(f *testing.F) {
+	for _, document := range documents {
+		f.Add(document)
+	}
+
+	f.Fuzz(func(t *testing.T, document string) {
+		normalized, err := Normalize(document)
+		if err != nil {
+			return
+		}
+
+		again, err := Normalize(normalized)
+		if err != nil || again != normalized {
+			t.Fatalf("Normalize(%s) = %s, %v, want it unchanged", normalized, again, err)
+		}
+
+		var original, result interface{}
+		if json.Unmarshal([]byte(document), &original) == nil {
+			if err := json.Unmarshal([]byte(normalized), &result); err != nil {
+				t.Fatalf("Normalize(%s) = %s, which is not valid JSON: %v", document, normalized, err)
+			}
+			if !bytes.Equal(marshal(t, original), marshal(t, result)) {
+				t.Fatalf("Normalize(%s) = %s, which has another value", document, normalized)
+			}
+		}
+
+		reordered, err := Normalize(string(reverseKeys(t, decodeNumbers(t, normalized))))
+		if err != nil || reordered != normalized {
+			t.Fatalf("Normalize of %s with its keys reversed = %s, %v, want %s", normalized, reordered, err, normalized)
+		}
+	})
+}
+
+// FuzzContains checks the properties of Contains as a subset relation: every
+// value contains itself, values with keys removed are contained in it,
+// permuted arrays match when unordered, ordered matches are unordered
+// matches, and values containing each other in order are equal.
+func FuzzContains(f *testing.F) {
+	for _, plan := range documents {
+		for _, state := range documents[len(documents)-6:] {
+			f.Add(plan, state, false)
+			f.Add(plan, state, true)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, planDocument, stateDocument string, unordered bool) {
+		var plan, state interface{}
+		if json.Unmarshal([]byte(planDocument), &plan) != nil || json.Unmarshal([]byte(stateDocument), &state) != nil {
+			return
+		}
+
+		if !Contains(plan, plan, unordered) {
+			t.Fatalf("%s doesn't contain itself", planDocument)
+		}
+		if pruned := withoutFirstKeys(plan); !Contains(pruned, plan, unordered) {
+			t.Fatalf("%s doesn't contain %s", planDocument, marshal(t, pruned))
+		}
+		if !Contains(plan, reverseArrays(plan), true) {
+			t.Fatalf("%s with its arrays reversed doesn't contain it when unordered", planDocument)
+		}
+
+		ordered := Contains(plan, state, false)
+		if ordered && !Contains(plan, state, true) {
+			t.Fatalf("%s contains %s in order, but not unordered", stateDocument, planDocument)
+		}
+		if ordered && Contains(state, plan, false) && !reflect.DeepEqual(plan, state) {
+			t.Fatalf("%s and %s contain each other, but differ", planDocument, stateDocument)
+		}
+	})
+}
+
+func decode(t *testing.T, document string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func decodeNumbers(t *testing.T, document string) interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func marshal(t *testing.T, value interface{}) []byte {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+// reverseKeys encodes value with the keys of its objects in reverse order.
+func reverseKeys(t *testing.T, value interface{}) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.Write(marshal(t, key))
+			buffer.WriteByte(':')
+			buffer.Write(reverseKeys(t, value[key]))
+		}
+		buffer.WriteByte('}')
+	case []interface{}:
+		buffer.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.Write(reverseKeys(t, item))
+		}
+		buffer.WriteByte(']')
+	default:
+		buffer.Write(marshal(t, value))
+	}
+	return buffer.Bytes()
+}
+
+// withoutFirstKeys returns a copy of value without the first key, in sorted
+// order, of each of its objects.
+func withoutFirstKeys(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pruned := map[string]interface{}{}
+		for i, key := range keys {
+			if i > 0 {
+				pruned[key] = withoutFirstKeys(value[key])
+			}
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(value))
+		for i, item := range value {
+			pruned[i] = withoutFirstKeys(item)
+		}
+		return pruned
+	}
+	return value
+}
+
+// reverseArrays returns a copy of value with the elements of its arrays in
+// reverse order.
+func reverseArrays(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		reversed := map[string]interface{}{}
+		for key, item := range value {
+			reversed[key] = reverseArrays(item)
+		}
+		return reversed
+	case []interface{}:
+		reversed := make([]interface{}, len(value))
+		for i, item := range value {
+			reversed[len(value)-1-i] = reverseArrays(item)
+		}
+		return reversed
+	}
+	return value
+}
@@ -0,0 +1,482 @@
+// Package mockserver implements an in-process emulation of the Hexagate API
+// for exercising the provider without a live account. It serves the monitor,
+// test notification, integration, alert stats, alert summary and protocol
+// endpoints under /api/v2, including list pagination, ETags, idempotency keys
+// and FastAPI style validation errors.
+package mockserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// APIPrefix is the path under which the API is served.
+const APIPrefix = "/api/v2"
+
+// DefaultPageSize is the page size of list responses when the request does
+// not set a limit.
+const DefaultPageSize = 100
+
+// Server is a running mock Hexagate API.
+type Server struct {
+	// API holds the server's state. Integrations, categories, alert stats,
+	// alert summaries and protocols can be seeded through it.
+	API *provider.FakeMonitorAPI
+
+	token  string
+	server *httptest.Server
+
+	mu              sync.Mutex
+	idempotencyKeys map[string]int
+}
+
+// New starts a mock server that accepts the given API token, either in the
+// X-Hexagate-Api-Key header or as a bearer token. The server must be closed
+// by the caller.
+func New(token string) *Server {
+	s := &Server{
+		API:             provider.NewFakeMonitorAPI(),
+		token:           token,
+		idempotencyKeys: map[string]int{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix+"/monitoring/user_monitors/", s.listMonitors)
+	mux.HandleFunc("POST "+APIPrefix+"/monitoring/user_monitors/", s.createMonitor)
+	mux.HandleFunc("GET "+APIPrefix+"/monitoring/user_monitors/{id}", s.getMonitor)
+	mux.HandleFunc("PUT "+APIPrefix+"/monitoring/user_monitors/{id}", s.updateMonitor)
+	mux.HandleFunc("PATCH "+APIPrefix+"/monitoring/user_monitors/{id}", s.patchMonitor)
+	mux.HandleFunc("DELETE "+APIPrefix+"/monitoring/user_monitors/{id}", s.deleteMonitor)
+	mux.HandleFunc("POST "+APIPrefix+"/monitoring/user_monitors/{id}/test_notification", s.testNotification)
+	mux.HandleFunc("GET "+APIPrefix+"/integrations/", s.listIntegrations)
+	mux.HandleFunc("GET "+APIPrefix+"/monitoring/categories/", s.listCategories)
+	mux.HandleFunc("GET "+APIPrefix+"/monitoring/alerts/stats", s.alertStats)
+	mux.HandleFunc("GET "+APIPrefix+"/monitoring/alerts/summary", s.alertSummary)
+	mux.HandleFunc("GET "+APIPrefix+"/protocols/", s.searchProtocols)
+
+	s.server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// URL returns the API URL to configure the provider's api_url with.
+func (s *Server) URL() string {
+	return s.server.URL + APIPrefix
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Hexagate-Api-Key")
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			token = bearer
+		}
+		if requestID := r.Header.Get("X-Request-Id"); requestID != "" {
+			w.Header().Set("X-Request-Id", requestID)
+		}
+		if token != s.token {
+			writeError(w, http.StatusUnauthorized, "invalid API token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// listMonitors serves a page of monitors. The limit and offset query
+// parameters select the page; the response links the next page, if any.
+func (s *Server) listMonitors(w http.ResponseWriter, r *http.Request) {
+	monitors, err := s.API.GetAllMonitors(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	limit, offset, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+
+	page := []*provider.Monitor{}
+	if offset < len(monitors) {
+		page = monitors[offset:min(offset+limit, len(monitors))]
+	}
+
+	var next *string
+	if offset+limit < len(monitors) {
+		query := r.URL.Query()
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset+limit))
+		link := fmt.Sprintf("%s?%s", r.URL.Path, query.Encode())
+		next = &link
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"items": page,
+		"total": len(monitors),
+		"next":  next,
+	})
+}
+
+func (s *Server) createMonitor(w http.ResponseWriter, r *http.Request) {
+	monitor, ok := decodeMonitor(w, r)
+	if !ok {
+		return
+	}
+
+	// Replays of a create with the same idempotency key return the monitor
+	// created by the first request.
+	key := r.Header.Get("Idempotency-Key")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := s.idempotencyKeys[key]; ok && key != "" {
+		writeJSON(w, http.StatusCreated, provider.CreateMonitorResponse{ID: id})
+		return
+	}
+
+	created, err := s.API.CreateMonitor(r.Context(), monitor)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if key != "" {
+		s.idempotencyKeys[key] = created.ID
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) getMonitor(w http.ResponseWriter, r *http.Request) {
+	id, ok := monitorID(w, r)
+	if !ok {
+		return
+	}
+
+	monitor, err := s.API.GetMonitor(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	body, err := json.Marshal(monitor)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	etag := bodyETag(body)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func (s *Server) updateMonitor(w http.ResponseWriter, r *http.Request) {
+	id, ok := monitorID(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.checkIfMatch(w, r, id) {
+		return
+	}
+
+	monitor, ok := decodeMonitor(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.API.UpdateMonitor(r.Context(), id, monitor); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	updated, err := s.API.GetMonitor(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) patchMonitor(w http.ResponseWriter, r *http.Request) {
+	id, ok := monitorID(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.checkIfMatch(w, r, id) {
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeValidationError(w, []interface{}{"body"}, fmt.Sprintf("invalid JSON: %s", err))
+		return
+	}
+	if name, ok := fields["name"]; ok && (name == nil || name == "") {
+		writeValidationError(w, []interface{}{"body", "name"}, "field required")
+		return
+	}
+
+	if err := s.API.PatchMonitor(r.Context(), id, fields); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	updated, err := s.API.GetMonitor(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deleteMonitor(w http.ResponseWriter, r *http.Request) {
+	id, ok := monitorID(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.checkIfMatch(w, r, id) {
+		return
+	}
+
+	if err := s.API.DeleteMonitor(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) testNotification(w http.ResponseWriter, r *http.Request) {
+	id, ok := monitorID(w, r)
+	if !ok {
+		return
+	}
+
+	var test provider.TestNotificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&test); err != nil {
+		writeValidationError(w, []interface{}{"body"}, fmt.Sprintf("invalid JSON: %s", err))
+		return
+	}
+
+	results, err := s.API.SendTestNotification(r.Context(), id, &test)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": nonNil(results)})
+}
+
+func (s *Server) listIntegrations(w http.ResponseWriter, r *http.Request) {
+	integrations, err := s.API.GetAllIntegrations(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": nonNil(integrations)})
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.API.GetAllCategories(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": nonNil(categories)})
+}
+
+func (s *Server) alertStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var start, end time.Time
+	for name, value := range map[string]*time.Time{"start_time": &start, "end_time": &end} {
+		parsed, err := time.Parse(time.RFC3339, query.Get(name))
+		if err != nil {
+			writeValidationError(w, []interface{}{"query", name}, "invalid datetime format")
+			return
+		}
+		*value = parsed
+	}
+
+	monitor := 0
+	if value := query.Get("user_monitor_id"); value != "" {
+		var err error
+		if monitor, err = strconv.Atoi(value); err != nil {
+			writeValidationError(w, []interface{}{"query", "user_monitor_id"}, "value is not a valid integer")
+			return
+		}
+	}
+
+	stats, err := s.API.GetAlertStats(r.Context(), start, end, monitor)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": nonNil(stats)})
+}
+
+func (s *Server) alertSummary(w http.ResponseWriter, r *http.Request) {
+	monitor, err := strconv.Atoi(r.URL.Query().Get("user_monitor_id"))
+	if err != nil {
+		writeValidationError(w, []interface{}{"query", "user_monitor_id"}, "value is not a valid integer")
+		return
+	}
+
+	summary, err := s.API.GetAlertSummary(r.Context(), monitor)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) searchProtocols(w http.ResponseWriter, r *http.Request) {
+	protocols, err := s.API.SearchProtocols(r.Context(), r.URL.Query().Get("name"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": nonNil(protocols)})
+}
+
+// checkIfMatch enforces the If-Match header of a request, writing a 412
+// response if the monitor's current ETag doesn't match.
+func (s *Server) checkIfMatch(w http.ResponseWriter, r *http.Request, id int) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return true
+	}
+
+	monitor, err := s.API.GetMonitor(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return false
+	}
+	body, err := json.Marshal(monitor)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if ifMatch != "*" && ifMatch != bodyETag(body) {
+		writeError(w, http.StatusPreconditionFailed, "the monitor has been modified")
+		return false
+	}
+	return true
+}
+
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// decodeMonitor decodes and validates a monitor request body, writing a 422
+// response for invalid monitors.
+func decodeMonitor(w http.ResponseWriter, r *http.Request) (*provider.Monitor, bool) {
+	var monitor provider.Monitor
+	if err := json.NewDecoder(r.Body).Decode(&monitor); err != nil {
+		writeValidationError(w, []interface{}{"body"}, fmt.Sprintf("invalid JSON: %s", err))
+		return nil, false
+	}
+
+	if monitor.Name == "" {
+		writeValidationError(w, []interface{}{"body", "name"}, "field required")
+		return nil, false
+	}
+	for i, rule := range monitor.MonitorRules {
+		if rule.Threshold < 0 {
+			writeValidationError(w, []interface{}{"body", "monitor_rules", i, "threshold"}, "ensure this value is greater than or equal to 0")
+			return nil, false
+		}
+		for j, channel := range rule.Channels {
+			if channel.Name == "" && !channel.IsReference() {
+				writeValidationError(w, []interface{}{"body", "monitor_rules", i, "channels", j, "name"}, "field required")
+				return nil, false
+			}
+		}
+	}
+
+	return &monitor, true
+}
+
+func monitorID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeValidationError(w, []interface{}{"path", "id"}, "value is not a valid integer")
+		return 0, false
+	}
+	return id, true
+}
+
+func pagination(w http.ResponseWriter, r *http.Request) (limit, offset int, ok bool) {
+	limit = DefaultPageSize
+	for name, value := range map[string]*int{"limit": &limit, "offset": &offset} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || (name == "limit" && parsed == 0) {
+			writeValidationError(w, []interface{}{"query", name}, "value is not a valid page parameter")
+			return 0, 0, false
+		}
+		*value = parsed
+	}
+	return limit, offset, true
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func writeValidationError(w http.ResponseWriter, loc []interface{}, msg string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"detail": []map[string]interface{}{{"loc": loc, "msg": msg}},
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	var apiErr *provider.APIError
+	if errors.As(err, &apiErr) {
+		writeError(w, apiErr.StatusCode, apiErr.Message)
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,268 @@
+// Package vcr records the API requests of the provider to a cassette file and
+// replays them, so that the provider can be exercised against responses of
+// the live API without credentials. A Recorder's Middleware method is used
+// with provider.WithMiddleware.
+//
+// Request headers are never recorded, so cassettes don't contain the API key
+// or bearer tokens. Response bodies are recorded as they are: cassettes of
+// monitors with channels contain the channel params and should be recorded
+// against an account without real secrets.
+package vcr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails requests that
+	// have no recorded interaction left.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records them, replacing the
+	// cassette when saved.
+	ModeRecord
+)
+
+// Cassette is the recorded interactions, in the order they were sent.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response the API returned for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+
+	used bool
+}
+
+// Request identifies a recorded request. Only the method, path, query and
+// body are recorded and matched; hosts and headers differ between runs.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response. Compressed bodies are recorded
+// decompressed.
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// recordedHeaders are the response headers kept in cassettes. The provider
+// uses them for optimistic locking and retries.
+var recordedHeaders = []string{"Content-Type", "ETag", "Retry-After"}
+
+// ErrNoInteraction is returned in replay mode for requests that don't match
+// any unused interaction of the cassette.
+var ErrNoInteraction = errors.New("vcr: no recorded interaction matches the request")
+
+// Recorder records or replays the interactions of a cassette file.
+type Recorder struct {
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// New returns a Recorder for the cassette at path. In replay mode, the
+// cassette is loaded and must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("vcr: decoding cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Middleware wraps the transport of the API client. It has the signature of
+// provider.Middleware.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		recorded, err := newRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.mode == ModeReplay {
+			return r.replay(req, recorded)
+		}
+		return r.record(req, recorded, next)
+	})
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing
+// in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Unused returns the interactions that were not replayed, so that callers can
+// check that a run sent every recorded request.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if !interaction.used {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.used || !interaction.Request.matches(recorded) {
+			continue
+		}
+		interaction.used = true
+
+		resp := &http.Response{
+			StatusCode: interaction.Response.StatusCode,
+			Status:     fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			Request:    req,
+		}
+		resp.ContentLength = int64(len(interaction.Response.Body))
+		for name, value := range interaction.Response.Headers {
+			resp.Header.Set(name, value)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request, next http.RoundTripper) (*http.Response, error) {
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("vcr: decompressing response: %w", err)
+		}
+		if body, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("vcr: decompressing response: %w", err)
+		}
+	}
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    map[string]string{},
+			Body:       string(body),
+		},
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			interaction.Response.Headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// newRequest captures the parts of a request that are recorded, restoring its
+// body for the next transport.
+func newRequest(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = string(body)
+	return recorded, nil
+}
+
+// matches reports whether two requests have the same method, path, query and
+// body. JSON bodies are compared by value, so that key order and formatting
+// don't matter.
+func (r Request) matches(other Request) bool {
+	if r.Method != other.Method || r.Path != other.Path || r.Query != other.Query {
+		return false
+	}
+	if r.Body == other.Body {
+		return true
+	}
+
+	var a, b interface{}
+	if json.Unmarshal([]byte(r.Body), &a) != nil || json.Unmarshal([]byte(other.Body), &b) != nil {
+		return false
+	}
+	encodedA, _ := json.Marshal(a)
+	encodedB, _ := json.Marshal(b)
+	return bytes.Equal(encodedA, encodedB)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
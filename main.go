@@ -3,7 +3,11 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/smartcontracts/terraform-provider-hexagate/provider"
@@ -13,17 +17,74 @@ var version = "dev"
 
 func main() {
 	var debug bool
+	var export bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers")
+	flag.BoolVar(&export, "export", false, "write the configuration and import blocks of every monitor of the account to stdout, and exit")
 	flag.Parse()
 
+	if export {
+		if err := runExport(context.Background()); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/smartcontracts/hexagate",
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	shutdownTracing, err := provider.SetupTracing(context.Background(), version)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+
+	err = providerserver.Serve(context.Background(), provider.New(version), opts)
+	_ = shutdownTracing(context.Background())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// runExport writes the configuration of the monitors of the account of
+// apiClientFromEnv to stdout. The client is read-only, so exporting can't
+// change the account.
+func runExport(ctx context.Context) error {
+	client, err := apiClientFromEnv("export")
+	if err != nil {
+		return err
+	}
+	client.ReadOnly = true
+
+	count, err := provider.ExportMonitors(ctx, client, os.Stdout)
+	if err != nil {
+		return err
+	}
+	log.Printf("exported %d monitors", count)
+	return nil
+}
+
+// apiClientFromEnv returns a client for the account of the HEXAGATE_API_TOKEN
+// environment variable. HEXAGATE_API_URL overrides the API the client sends
+// requests to. The mode is added to the user agent.
+func apiClientFromEnv(mode string) (*provider.HexagateClient, error) {
+	token := os.Getenv("HEXAGATE_API_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("HEXAGATE_API_TOKEN must be set for the %s", mode)
+	}
+	apiURL := os.Getenv("HEXAGATE_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.hexagate.com/api/v2"
+	}
+
+	return &provider.HexagateClient{
+		APIToken:     token,
+		BaseURL:      apiURL,
+		Client:       &http.Client{Timeout: time.Minute},
+		UserAgent:    "terraform-provider-hexagate/" + version + " (" + mode + ")",
+		MaxRetries:   3,
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 30 * time.Second,
+	}, nil
 }
@@ -0,0 +1,200 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/smartcontracts/terraform-provider-hexagate/internal/mockserver"
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// The acceptance tests run against an in-process mock of the Hexagate API by
+// default. With TF_ACC set, they run against the account of
+// HEXAGATE_API_TOKEN instead, at HEXAGATE_API_URL if set:
+//
+//	TF_ACC=1 HEXAGATE_API_TOKEN=... go test ./provider -run TestAcc
+//
+// The monitors they create are named with accTestPrefix, so that the sweeper
+// deletes them if a failed run leaves them behind.
+
+// accTestPrefix is the name prefix of the monitors created by acceptance
+// tests. The sweeper only deletes monitors with this prefix.
+const accTestPrefix = "tf-acc-test-"
+
+// accMockToken is the API token of the mock API.
+const accMockToken = "tf-acc-test-token"
+
+// accProviderConfig returns the provider configuration for the API the
+// acceptance tests run against.
+func accProviderConfig(t *testing.T) string {
+	t.Helper()
+	config := map[string]interface{}{}
+	if os.Getenv("TF_ACC") == "" {
+		server := mockserver.New(accMockToken)
+		t.Cleanup(server.Close)
+		config["api_token"] = accMockToken
+		config["api_url"] = server.URL()
+	} else {
+		config["api_token"] = os.Getenv("HEXAGATE_API_TOKEN")
+		if config["api_token"] == "" {
+			t.Fatal("HEXAGATE_API_TOKEN must be set for acceptance tests with TF_ACC")
+		}
+		if url := os.Getenv("HEXAGATE_API_URL"); url != "" {
+			config["api_url"] = url
+		}
+	}
+
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(encoded)
+}
+
+// newAccTerraform returns a testTerraform for hexagate_monitor configured with
+// providerConfig. The monitor is destroyed when the test ends, unless the
+// test destroyed or forgot it.
+func newAccTerraform(t *testing.T, providerConfig string, opts ...provider.Option) *testTerraform {
+	t.Helper()
+	tf := newTestTerraform(t, provider.New("test", opts...)(), providerConfig, "hexagate_monitor")
+	t.Cleanup(func() {
+		if !tf.state.IsNull() {
+			if err := tf.destroy(); err != nil {
+				t.Errorf("destroying the monitor: %s", err)
+			}
+		}
+	})
+	return tf
+}
+
+// accTestName returns a name for a monitor that no other test run uses.
+func accTestName() string {
+	return fmt.Sprintf("%s%x", accTestPrefix, rand.Uint32())
+}
+
+func accMonitorConfig(name string, monitorID int, description string, threshold int) string {
+	return fmt.Sprintf(`{
+		"name": %q,
+		"monitor_id": %d,
+		"description": %q,
+		"disabled": false,
+		"params": "{\"window\":\"1h\"}",
+		"entities": [{"entity_type": 1, "params": "{\"address\":\"0x1f98431c8ad98523631ae4a59f267346ea31f984\",\"chain_id\":1}"}],
+		"monitor_rules": [{
+			"name": "critical", "type": "notification", "threshold": %d, "categories": ["1"],
+			"channels": [{"name": "webhook", "params": "{\"url\":\"https://hooks.example.com/tf-acc-test\"}"}]
+		}]
+	}`, name, monitorID, description, threshold)
+}
+
+// TestAccMonitor runs a monitor through its lifecycle: creating it, updating
+// it in place, importing it by ID and by name, replacing it and destroying it.
+func TestAccMonitor(t *testing.T) {
+	providerConfig := accProviderConfig(t)
+	name := accTestName()
+	tf := newAccTerraform(t, providerConfig)
+
+	// Create
+	tf.apply(accMonitorConfig(name, 1, "created", 50))
+	id := stringValue(t, tf.attribute("id"))
+	if id == "" {
+		t.Fatal("the created monitor has no ID")
+	}
+	for attribute, want := range map[string]string{"name": name, "description": "created", "params": `{"window":"1h"}`} {
+		if got := stringValue(t, tf.attribute(attribute)); got != want {
+			t.Errorf("%s = %q, want %q", attribute, got, want)
+		}
+	}
+	ruleID := tf.attribute("monitor_rules", 0, "id")
+	if !ruleID.IsKnown() || ruleID.IsNull() {
+		t.Errorf("the rule has no ID: %s", ruleID)
+	}
+
+	// Update in place
+	config := accMonitorConfig(name, 1, "updated", 90)
+	if plan := tf.apply(config); plan.replace {
+		t.Fatal("updating the description and threshold replaces the monitor")
+	}
+	if got := stringValue(t, tf.attribute("id")); got != id {
+		t.Errorf("ID after the update = %s, want %s", got, id)
+	}
+	if got := tf.attribute("monitor_rules", 0, "id"); !got.Equal(ruleID) {
+		t.Errorf("rule ID after the update = %s, want %s", got, ruleID)
+	}
+	if got := int64Value(t, tf.attribute("monitor_rules", 0, "threshold")); got != 90 {
+		t.Errorf("threshold after the update = %d, want 90", got)
+	}
+
+	// Import
+	for _, importID := range []string{id, "name=" + name} {
+		imported := newAccTerraform(t, providerConfig)
+		if err := imported.importState(importID); err != nil {
+			t.Fatalf("importing %s: %s", importID, err)
+		}
+		imported.assertNoChanges(config)
+		imported.forget()
+	}
+
+	// Replace
+	plan := tf.apply(accMonitorConfig(name, 2, "updated", 90))
+	if !plan.replace {
+		t.Fatal("changing monitor_id doesn't replace the monitor")
+	}
+	replacedID := stringValue(t, tf.attribute("id"))
+	if replacedID == id {
+		t.Errorf("ID after replacing the monitor = %s, want a new one", replacedID)
+	}
+	if err := newAccTerraform(t, providerConfig).importState(id); err == nil {
+		t.Errorf("the replaced monitor %s still exists", id)
+	}
+
+	// Destroy
+	if err := tf.destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newAccTerraform(t, providerConfig).importState(replacedID); err == nil {
+		t.Errorf("the destroyed monitor %s still exists", replacedID)
+	}
+}
+
+// TestAccMonitorDisappears checks that a monitor deleted outside Terraform is
+// removed from the state and planned to be created again.
+func TestAccMonitorDisappears(t *testing.T) {
+	providerConfig := accProviderConfig(t)
+	config := accMonitorConfig(accTestName(), 1, "", 50)
+	tf := newAccTerraform(t, providerConfig)
+	tf.apply(config)
+
+	outside := newAccTerraform(t, providerConfig)
+	if err := outside.importState(stringValue(t, tf.attribute("id"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := outside.destroy(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tf.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if !tf.state.IsNull() {
+		t.Fatal("the deleted monitor is still in the state")
+	}
+	tf.apply(config)
+}
+
+// TestAccMonitorValidationError checks that the API's validation errors are
+// reported with the field they are about.
+func TestAccMonitorValidationError(t *testing.T) {
+	tf := newAccTerraform(t, accProviderConfig(t))
+	_, err := tf.tryApply(accMonitorConfig("", 1, "", 50))
+	if err == nil {
+		t.Fatal("creating a monitor without a name succeeded")
+	}
+	if !strings.Contains(err.Error(), "name") {
+		t.Errorf("the error doesn't name the invalid field: %s", err)
+	}
+}
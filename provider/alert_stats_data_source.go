@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AlertStatsDataSource{}
+
+func NewAlertStatsDataSource() datasource.DataSource {
+	return &AlertStatsDataSource{}
+}
+
+type AlertStatsDataSource struct {
+	client *Client
+}
+
+// AlertStatsDataSourceModel describes the data source data model.
+type AlertStatsDataSourceModel struct {
+	Window    types.String     `tfsdk:"window"`
+	Monitor   types.String     `tfsdk:"monitor"`
+	StartTime types.String     `tfsdk:"start_time"`
+	EndTime   types.String     `tfsdk:"end_time"`
+	Total     types.Int64      `tfsdk:"total"`
+	Stats     []AlertStatModel `tfsdk:"stats"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+// AlertStatModel describes the alert volume for a single monitor and category.
+type AlertStatModel struct {
+	Monitor  types.String `tfsdk:"monitor"`
+	Category types.Int64  `tfsdk:"category"`
+	Count    types.Int64  `tfsdk:"count"`
+}
+
+func (d *AlertStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AlertStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_stats"
+}
+
+func (d *AlertStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Summarizes alert volume per monitor and category over a time window.",
+		Attributes: map[string]schema.Attribute{
+			"window": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The time window to summarize, ending now, as a Go duration string (e.g. \"24h\", \"168h\"). Defaults to \"24h\".",
+			},
+			"monitor": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include alerts raised by the monitor with this ID.",
+			},
+			"start_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The start of the summarized window.",
+			},
+			"end_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The end of the summarized window.",
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The total number of alerts in the window.",
+			},
+			"stats": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The alert volume per monitor and category.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"monitor": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the monitor that raised the alerts.",
+						},
+						"category": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The category of the alerts.",
+						},
+						"count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of alerts.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *AlertStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state AlertStatsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Default values
+	window := 24 * time.Hour
+	if !state.Window.IsNull() {
+		var err error
+		window, err = time.ParseDuration(state.Window.ValueString())
+		if err != nil || window <= 0 {
+			resp.Diagnostics.AddError(
+				"Invalid Alert Stats Window",
+				fmt.Sprintf("The window %q is not a positive duration.", state.Window.ValueString()),
+			)
+			return
+		}
+	}
+
+	monitorID := 0
+	if !state.Monitor.IsNull() {
+		var err error
+		monitorID, err = strconv.Atoi(state.Monitor.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Alert Stats",
+				fmt.Sprintf("Could not parse monitor ID: %s", err),
+			)
+			return
+		}
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-window)
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stats, err := client.GetAlertStats(ctx, start, end, monitorID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Alert Stats",
+			fmt.Sprintf("Could not read alert stats: %s", err),
+		)
+		return
+	}
+
+	var total int64
+	state.Stats = make([]AlertStatModel, len(stats))
+	for i, stat := range stats {
+		state.Stats[i] = AlertStatModel{
+			Monitor:  types.StringValue(strconv.Itoa(stat.MonitorID)),
+			Category: types.Int64Value(int64(stat.Category)),
+			Count:    types.Int64Value(int64(stat.Count)),
+		}
+		total += int64(stat.Count)
+	}
+
+	if state.Window.IsNull() {
+		state.Window = types.StringValue("24h")
+	}
+	state.StartTime = types.StringValue(start.Format(time.RFC3339))
+	state.EndTime = types.StringValue(end.Format(time.RFC3339))
+	state.Total = types.Int64Value(total)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
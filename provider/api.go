@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// MonitorAPI is the subset of the Hexagate API used by resources and data
+// sources. HexagateClient implements it against the live API and
+// FakeMonitorAPI in memory.
+type MonitorAPI interface {
+	// WithRequestOptions returns an API with the given request options
+	// applied. Unset options keep the receiver's values.
+	WithRequestOptions(opts *RequestOptionsModel) (MonitorAPI, diag.Diagnostics)
+
+	CreateMonitor(ctx context.Context, monitor *Monitor) (*CreateMonitorResponse, error)
+	GetMonitor(ctx context.Context, id int) (*Monitor, error)
+	UpdateMonitor(ctx context.Context, id int, monitor *Monitor) error
+	PatchMonitor(ctx context.Context, id int, fields map[string]interface{}) error
+	DeleteMonitor(ctx context.Context, id int) error
+	GetAllMonitors(ctx context.Context) ([]*Monitor, error)
+
+	GetAllIntegrations(ctx context.Context) ([]*Integration, error)
+	GetAllCategories(ctx context.Context) ([]*Category, error)
+	GetAlertStats(ctx context.Context, start, end time.Time, monitorID int) ([]*AlertStat, error)
+	GetAlertSummary(ctx context.Context, monitorID int) (*AlertSummary, error)
+	SendTestNotification(ctx context.Context, monitorID int, test *TestNotificationRequest) ([]*TestNotificationResult, error)
+	SearchProtocols(ctx context.Context, name string) ([]*Protocol, error)
+}
+
+var _ MonitorAPI = &HexagateClient{}
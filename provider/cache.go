@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ETagCache stores response bodies by URL together with the ETag the API
+// returned for them, so that unchanged resources can be revalidated with a
+// conditional request instead of downloaded again.
+type ETagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+func NewETagCache() *ETagCache {
+	return &ETagCache{entries: make(map[string]etagEntry)}
+}
+
+// Get returns the cached ETag and body for url, if any.
+func (c *ETagCache) Get(url string) (string, []byte, bool) {
+	if c == nil {
+		return "", nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	return entry.etag, entry.body, ok
+}
+
+// Put caches body for url under etag.
+func (c *ETagCache) Put(url, etag string, body []byte) {
+	if c == nil || etag == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[url] = etagEntry{etag: etag, body: body}
+}
+
+// Invalidate drops the cached entry for url.
+func (c *ETagCache) Invalidate(url string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, url)
+}
+
+// MonitorListCache memoizes the monitor list for a short time, so that a
+// single Terraform operation lists monitors at most once. Concurrent callers
+// wait for the in-flight list instead of issuing their own.
+type MonitorListCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	monitors []*Monitor
+	expires  time.Time
+	// pending is the in-flight fetch, if any. generation is incremented by
+	// Invalidate, so that a fetch started before it isn't cached.
+	pending    *monitorListCall
+	generation int
+}
+
+// monitorListCall is a fetch of the monitor list shared by the callers waiting
+// for it. monitors and err are set before done is closed.
+type monitorListCall struct {
+	done     chan struct{}
+	monitors []*Monitor
+	err      error
+}
+
+func NewMonitorListCache(ttl time.Duration) *MonitorListCache {
+	return &MonitorListCache{ttl: ttl}
+}
+
+// Get returns the cached monitor list, calling fetch when it is missing or
+// expired. The fetch is shared by concurrent callers and doesn't stop when
+// the context of one of them is canceled; each caller stops waiting for it
+// when its own context is done.
+func (c *MonitorListCache) Get(ctx context.Context, fetch func(context.Context) ([]*Monitor, error)) ([]*Monitor, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if c.monitors != nil && !time.Now().After(c.expires) {
+		monitors := append([]*Monitor(nil), c.monitors...)
+		c.mu.Unlock()
+		return monitors, nil
+	}
+
+	call := c.pending
+	if call == nil {
+		call = &monitorListCall{done: make(chan struct{})}
+		c.pending = call
+		go c.fetch(context.WithoutCancel(ctx), call, c.generation, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		return append([]*Monitor(nil), call.monitors...), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch runs call, caching its result unless the cache was invalidated since
+// generation.
+func (c *MonitorListCache) fetch(ctx context.Context, call *monitorListCall, generation int, fetch func(context.Context) ([]*Monitor, error)) {
+	monitors, err := fetch(ctx)
+
+	c.mu.Lock()
+	if c.pending == call {
+		c.pending = nil
+	}
+	if err == nil && c.generation == generation {
+		c.monitors = monitors
+		c.expires = time.Now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+
+	call.monitors, call.err = monitors, err
+	close(call.done)
+}
+
+// Invalidate drops the cached list, e.g. after a monitor was written. Callers
+// arriving after it don't wait for a fetch started before it.
+func (c *MonitorListCache) Invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.monitors = nil
+	c.pending = nil
+	c.generation++
+}
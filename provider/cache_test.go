@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMonitorListCacheCanceledCaller(t *testing.T) {
+	cache := NewMonitorListCache(time.Minute)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]*Monitor, error) {
+		fetches.Add(1)
+		select {
+		case <-release:
+			return []*Monitor{{ID: 1}}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The first caller gives up while the list is being fetched.
+	canceled, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := cache.Get(canceled, fetch)
+		firstErr <- err
+	}()
+	for fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]*Monitor, 5)
+	errs := make([]error, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = cache.Get(context.Background(), fetch)
+		}()
+	}
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller got %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	wg.Wait()
+	for i := range results {
+		if errs[i] != nil || len(results[i]) != 1 {
+			t.Errorf("caller %d got %v, %v, want the fetched list", i, results[i], errs[i])
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times, want 1", n)
+	}
+
+	if _, err := cache.Get(context.Background(), fetch); err != nil {
+		t.Fatal(err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times after the list was cached, want 1", n)
+	}
+}
+
+func TestMonitorListCacheInvalidateDuringFetch(t *testing.T) {
+	cache := NewMonitorListCache(time.Minute)
+
+	var fetches atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	stale := func(ctx context.Context) ([]*Monitor, error) {
+		fetches.Add(1)
+		close(started)
+		<-release
+		return []*Monitor{{ID: 1}}, nil
+	}
+	fresh := func(ctx context.Context) ([]*Monitor, error) {
+		fetches.Add(1)
+		return []*Monitor{{ID: 1}, {ID: 2}}, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.Get(context.Background(), stale)
+	}()
+	<-started
+
+	// A monitor is written while the list is being fetched.
+	cache.Invalidate()
+	monitors, err := cache.Get(context.Background(), fresh)
+	if err != nil || len(monitors) != 2 {
+		t.Fatalf("got %v, %v after invalidating, want the fresh list", monitors, err)
+	}
+
+	close(release)
+	<-done
+	monitors, err = cache.Get(context.Background(), fresh)
+	if err != nil || len(monitors) != 2 {
+		t.Errorf("got %v, %v, want the fetch started before invalidating not to be cached", monitors, err)
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// categoryResolver translates between the names and IDs of alert categories.
+// Rule categories can be configured by either. The categories are only
+// fetched from the API when a name has to be translated, and at most once.
+type categoryResolver struct {
+	client MonitorAPI
+
+	loaded bool
+	ids    map[string]int
+}
+
+func newCategoryResolver(client MonitorAPI) *categoryResolver {
+	return &categoryResolver{client: client}
+}
+
+// id returns the ID of the category given by value, either its ID or its name.
+func (c *categoryResolver) id(ctx context.Context, value string) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+
+	if !c.loaded {
+		categories, err := c.client.GetAllCategories(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("listing categories: %w", err)
+		}
+		c.ids = make(map[string]int, len(categories))
+		for _, category := range categories {
+			c.ids[category.Name] = category.ID
+		}
+		c.loaded = true
+	}
+
+	id, ok := c.ids[value]
+	if !ok {
+		names := make([]string, 0, len(c.ids))
+		for name := range c.ids {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return 0, fmt.Errorf("unknown category %q, expected a category ID or one of: %s", value, strings.Join(names, ", "))
+	}
+	return id, nil
+}
+
+// resolveMonitor sets the category IDs of the monitor's rules whose
+// categories were configured by name.
+func (c *categoryResolver) resolveMonitor(ctx context.Context, monitor *Monitor) error {
+	for i := range monitor.MonitorRules {
+		rule := &monitor.MonitorRules[i]
+		if rule.categoryNames == nil {
+			continue
+		}
+
+		rule.Categories = make([]int, 0, len(rule.categoryNames))
+		for _, name := range rule.categoryNames {
+			id, err := c.id(ctx, name)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			rule.Categories = append(rule.Categories, id)
+		}
+		rule.categoryNames = nil
+	}
+	return nil
+}
+
+// categoriesValue converts the category IDs of a rule returned by the API into
+// the categories attribute. Categories that the prior value referred to by
+// name keep their name, all others are given by ID.
+func (c *categoryResolver) categoriesValue(ctx context.Context, ids []int, prior types.List) (types.List, error) {
+	names := map[int]string{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		for _, element := range prior.Elements() {
+			value, ok := element.(types.String)
+			if !ok || value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			if _, err := strconv.Atoi(value.ValueString()); err == nil {
+				continue
+			}
+			id, err := c.id(ctx, value.ValueString())
+			if err != nil {
+				return types.ListNull(types.StringType), err
+			}
+			names[id] = value.ValueString()
+		}
+	}
+
+	values := make([]attr.Value, len(ids))
+	for i, id := range ids {
+		if name, ok := names[id]; ok {
+			values[i] = types.StringValue(name)
+		} else {
+			values[i] = types.StringValue(strconv.Itoa(id))
+		}
+	}
+	return types.ListValueMust(types.StringType, values), nil
+}
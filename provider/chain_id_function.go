@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ChainIDFunction{}
+
+func NewChainIDFunction() function.Function {
+	return &ChainIDFunction{}
+}
+
+// ChainIDFunction resolves the name of a chain to the chain ID used in the
+// params of entities, wallets and factory entities.
+type ChainIDFunction struct{}
+
+// chainIDs maps chain names to their EVM chain ID, which is the chain ID
+// Hexagate expects. Common alternative names are included as well.
+var chainIDs = map[string]int64{
+	"ethereum":      1,
+	"mainnet":       1,
+	"optimism":      10,
+	"bsc":           56,
+	"bnb":           56,
+	"gnosis":        100,
+	"polygon":       137,
+	"fantom":        250,
+	"zksync":        324,
+	"mantle":        5000,
+	"base":          8453,
+	"arbitrum":      42161,
+	"arbitrum_nova": 42170,
+	"celo":          42220,
+	"avalanche":     43114,
+	"linea":         59144,
+	"blast":         81457,
+	"scroll":        534352,
+
+	// Testnets.
+	"holesky":          17000,
+	"base_sepolia":     84532,
+	"arbitrum_sepolia": 421614,
+	"sepolia":          11155111,
+}
+
+func (f *ChainIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chain_id"
+}
+
+func (f *ChainIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the ID of a chain",
+		Description: "Returns the chain ID of a chain given by name, e.g. 42161 for \"arbitrum\", for use as the chain_id of entities, wallets and factory entities. " +
+			"Names are case-insensitive, and an unknown name fails with the list of known names.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name of the chain",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *ChainIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	id, ok := chainID(name)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown chain %q. Known chains are: %s", name, strings.Join(chainNames(), ", ")))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, id)
+}
+
+// chainID returns the chain ID of a chain name. Spaces in the name are
+// treated like underscores, so "Arbitrum Nova" is arbitrum_nova.
+func chainID(name string) (int64, bool) {
+	id, ok := chainIDs[strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")]
+	return id, ok
+}
+
+// chainNames returns the sorted names chainID accepts.
+func chainNames() []string {
+	names := make([]string, 0, len(chainIDs))
+	for name := range chainIDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &ChannelParamsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ChannelParamsEphemeralResource{}
+)
+
+func NewChannelParamsEphemeralResource() ephemeral.EphemeralResource {
+	return &ChannelParamsEphemeralResource{}
+}
+
+// ChannelParamsEphemeralResource reads the params of a notification channel,
+// such as webhook URLs and integration keys, without writing them to state.
+type ChannelParamsEphemeralResource struct {
+	client *Client
+}
+
+// ChannelParamsEphemeralResourceModel describes the ephemeral resource data
+// model.
+type ChannelParamsEphemeralResourceModel struct {
+	MonitorID types.String `tfsdk:"monitor_id"`
+	ChannelID types.Int64  `tfsdk:"channel_id"`
+	Name      types.String `tfsdk:"name"`
+	Params    types.String `tfsdk:"params"`
+}
+
+func (e *ChannelParamsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
+
+func (e *ChannelParamsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_params"
+}
+
+func (e *ChannelParamsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the params of a notification channel of a monitor, such as webhook URLs and integration keys, " +
+			"so that they can be passed to secret stores without being written to state.",
+		Attributes: map[string]schema.Attribute{
+			"monitor_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of a monitor with a rule that notifies the channel.",
+			},
+			"channel_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the channel.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the channel.",
+			},
+			"params": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The params of the channel, as a JSON string.",
+			},
+		},
+	}
+}
+
+func (e *ChannelParamsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var data ChannelParamsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitorID, err := strconv.Atoi(data.MonitorID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Channel Params",
+			fmt.Sprintf("Could not parse monitor ID: %s", err),
+		)
+		return
+	}
+
+	monitor, err := e.client.HexagateClient.GetMonitor(ctx, monitorID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Channel Params",
+			fmt.Sprintf("Could not read monitor %d: %s", monitorID, err),
+		)
+		return
+	}
+
+	channel, ok := findChannel(monitor, data.ChannelID.ValueInt64())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Channel Not Found",
+			fmt.Sprintf("No rule of monitor %d notifies a channel with ID %d, or the API didn't return its params.", monitorID, data.ChannelID.ValueInt64()),
+		)
+		return
+	}
+
+	params, err := json.Marshal(channel.Params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Channel Params",
+			fmt.Sprintf("Could not encode the params of channel %d: %s", channel.ID, err),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(channel.Name)
+	data.Params = types.StringValue(string(params))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// findChannel returns the channel of a monitor's rules with the given ID,
+// provided the API returned its params.
+func findChannel(monitor *Monitor, id int64) (Channel, bool) {
+	for _, rule := range monitor.MonitorRules {
+		for _, channel := range rule.Channels {
+			if int64(channel.ID) == id && channel.Params != nil {
+				return channel, true
+			}
+		}
+	}
+	return Channel{}, false
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker stops sending requests after a number of consecutive
+// transport errors, so that an unreachable API fails every pending operation
+// fast instead of letting each one time out on its own. After the cooldown a
+// single request is let through to probe whether the API has recovered.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	lastErr  error
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitOpenError is returned for requests rejected by an open circuit breaker.
+type CircuitOpenError struct {
+	Failures int
+	LastErr  error
+	RetryAt  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("the Hexagate API appears to be unavailable: %d consecutive requests failed (last error: %s); "+
+		"not sending further requests until %s", e.Failures, e.LastErr, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return e.LastErr
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns an error if requests are currently rejected.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+
+	retryAt := b.openedAt.Add(b.cooldown)
+	if time.Now().Before(retryAt) || b.probing {
+		return &CircuitOpenError{Failures: b.failures, LastErr: b.lastErr, RetryAt: retryAt}
+	}
+
+	b.probing = true
+	return nil
+}
+
+// Success records that a response was received, closing the breaker.
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.lastErr = nil
+	b.probing = false
+}
+
+// Failure records a transport error, opening the breaker once the threshold is reached.
+func (b *CircuitBreaker) Failure(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.lastErr = err
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// Cancel records that a request was abandoned without a response or a
+// transport error, e.g. because its context was cancelled. It releases the
+// probe of a half-open breaker, so that the next request can probe instead.
+func (b *CircuitBreaker) Cancel() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
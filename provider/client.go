@@ -2,58 +2,386 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// ErrReadOnly is returned for mutating requests when the client is read-only.
+var ErrReadOnly = errors.New("the provider is configured with read_only = true, refusing to modify Hexagate")
+
 type HexagateClient struct {
 	APIToken string
 	BaseURL  string
 	Client   *http.Client
+
+	// TokenSource, when set, provides bearer tokens that are sent instead of APIToken.
+	TokenSource TokenSource
+
+	// UserAgent is sent as the User-Agent header of every request.
+	UserAgent string
+
+	// ReadOnly makes every request that could mutate Hexagate fail before it is sent.
+	ReadOnly bool
+
+	// CircuitBreaker, when set, fails requests fast after repeated transport errors.
+	CircuitBreaker *CircuitBreaker
+
+	// Semaphore, when set, limits the number of requests in flight to its capacity.
+	Semaphore chan struct{}
+
+	// MaxResponseSize limits the size of a decompressed response body. Zero
+	// means no limit.
+	MaxResponseSize int64
+
+	// ETagCache, when set, is used to revalidate monitor reads with If-None-Match.
+	ETagCache *ETagCache
+	// MonitorListCache, when set, memoizes GetAllMonitors. It is invalidated by
+	// every monitor write made through this client.
+	MonitorListCache *MonitorListCache
+
+	// MaxRetries is the number of times a request is retried after a 429 or 5xx response.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// RetryJitter randomizes the backoff between zero and the computed wait,
+	// so that concurrent requests don't retry in lockstep.
+	RetryJitter bool
+	// RetryBudget bounds the total time spent on the requests of a resource
+	// or data source operation including their retries, or on a single
+	// request sent outside an operation. No retry is attempted that would
+	// exceed it. Zero means no limit.
+	RetryBudget time.Duration
+
+	// OrganizationID and Workspace scope every request to an organization and
+	// workspace of the account. They are omitted when empty.
+	OrganizationID string
+	Workspace      string
+
+	// Headers are added to every request, unless the request already sets them.
+	Headers map[string]string
+}
+
+// do sends the request, retrying with exponential backoff on 429 responses.
+// A Retry-After header on the response takes precedence over the computed
+// backoff. 5xx responses and transport errors are retried too when the
+// request is safe to repeat, i.e. idempotent or carrying an Idempotency-Key
+// header, since the API may have acted on it before failing.
+func (c *HexagateClient) do(req *http.Request) (*http.Response, error) {
+	if c.ReadOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, ErrReadOnly
+	}
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.OrganizationID != "" {
+		req.Header.Set("X-Hexagate-Organization-Id", c.OrganizationID)
+	}
+	if c.Workspace != "" {
+		req.Header.Set("X-Hexagate-Workspace", c.Workspace)
+	}
+	for name, value := range c.Headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+	// A request ID lets Hexagate support find the request in their logs. It
+	// stays the same across retries of the request.
+	if req.Header.Get("X-Request-Id") == "" {
+		if requestID, err := uuid.GenerateUUID(); err == nil {
+			req.Header.Set("X-Request-Id", requestID)
+		}
+	}
+	if etag := ifMatchFromContext(req.Context()); etag != "" && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		req.Header.Set("If-Match", etag)
+	}
+
+	deadline := retryDeadline(req.Context(), c.RetryBudget)
+	for attempt := 0; ; attempt++ {
+		if c.TokenSource != nil {
+			token, err := c.TokenSource.Token(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("obtaining OAuth2 token: %w", err)
+			}
+			req.Header.Del("X-Hexagate-Api-Key")
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		if err := c.CircuitBreaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		logRequest(req.Context(), req, attempt, c.Headers)
+		started := time.Now()
+		ctx, span := startRequestSpan(req, attempt)
+		resp, err := c.send(req.WithContext(ctx))
+		endRequestSpan(span, resp, err)
+		logResponse(req.Context(), req, resp, err, time.Since(started))
+		switch {
+		case err == nil:
+			c.CircuitBreaker.Success()
+		case req.Context().Err() == nil:
+			c.CircuitBreaker.Failure(err)
+		default:
+			c.CircuitBreaker.Cancel()
+		}
+		if err != nil {
+			if attempt >= c.MaxRetries || req.Context().Err() != nil || !isRepeatable(req) {
+				return nil, &RequestError{RequestID: req.Header.Get("X-Request-Id"), Err: err}
+			}
+		} else if attempt >= c.MaxRetries || !shouldRetry(req, resp.StatusCode) {
+			return resp, nil
+		}
+
+		// A wait that would outlast the retry budget or the deadline of the
+		// context fails the request now instead of after waiting in vain.
+		wait := c.backoff(attempt, resp)
+		var giveUp string
+		if ctxDeadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(ctxDeadline) {
+			giveUp = fmt.Sprintf("waiting %s to retry would exceed the deadline of the operation", wait)
+		}
+		if c.RetryBudget > 0 && time.Now().Add(wait).After(deadline) {
+			giveUp = fmt.Sprintf("retry budget of %s exhausted", c.RetryBudget)
+		}
+		if giveUp != "" {
+			tflog.Debug(req.Context(), "Not retrying request", map[string]interface{}{
+				"http_url": req.URL.String(),
+				"reason":   giveUp,
+			})
+			if err != nil {
+				return nil, &RequestError{RequestID: req.Header.Get("X-Request-Id"), Err: fmt.Errorf("%s: %w", giveUp, err)}
+			}
+			return resp, nil
+		}
+		recordRetry(req.Context(), resp, err)
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, &RequestError{RequestID: req.Header.Get("X-Request-Id"), Err: req.Context().Err()}
+		case <-timer.C:
+		}
+	}
 }
 
+// send performs a single attempt of the request, waiting for a free slot in
+// the semaphore first. The slot is held until the response headers arrive.
+func (c *HexagateClient) send(req *http.Request) (*http.Response, error) {
+	if c.Semaphore != nil {
+		select {
+		case c.Semaphore <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		defer func() { <-c.Semaphore }()
+	}
+
+	// Setting Accept-Encoding disables the transport's own decompression, so
+	// that the response size limit applies to the decompressed body.
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return prepareResponse(resp, c.MaxResponseSize)
+}
+
+// shouldRetry reports whether a response with the status code is retried. A
+// 429 response means the request was not processed, so it is always retried.
+func shouldRetry(req *http.Request, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return statusCode >= http.StatusInternalServerError && isRepeatable(req)
+}
+
+// isRepeatable reports whether sending the request more than once is safe.
+func isRepeatable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns how long to wait before the next attempt. resp is nil
+// when the previous attempt failed with a transport error. A Retry-After
+// header is honored in full, even beyond RetryWaitMax, since retrying earlier
+// would only be rejected again.
+func (c *HexagateClient) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+			if date, err := http.ParseTime(retryAfter); err == nil {
+				return max(time.Until(date), 0)
+			}
+		}
+	}
+
+	wait := c.RetryWaitMin << attempt
+	if wait <= 0 || wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+	if c.RetryJitter && wait > 0 {
+		wait = rand.N(wait + 1)
+	}
+	return wait
+}
+
+// Monitor is a user monitor as sent to and returned by the API.
 type Monitor struct {
 	ID           int                    `json:"id,omitempty"`
 	Name         string                 `json:"name"`
-	MonitorID    int                    `json:"monitor_id"`
+	MonitorID    int                    `json:"monitor_id,omitempty"`
 	Description  string                 `json:"description,omitempty"`
 	CreatedBy    string                 `json:"created_by,omitempty"`
 	CreatedAt    string                 `json:"created_at,omitempty"`
 	UpdatedAt    string                 `json:"updated_at,omitempty"`
-	Disabled     bool                   `json:"disabled,omitempty"`
-	Entities     []interface{}          `json:"entities,omitempty"`
-	MonitorTags  []string               `json:"monitor_tags,omitempty"`
-	MonitorRules []interface{}          `json:"monitor_rules"`
+	Disabled     bool                   `json:"disabled"`
+	Entities     []Entity               `json:"entities"`
+	Wallets      []Wallet               `json:"wallets"`
+	MonitorTags  []string               `json:"monitor_tags"`
+	EntitiesTags []string               `json:"entities_tags"`
+	MonitorRules []Rule                 `json:"monitor_rules"`
 	Params       map[string]interface{} `json:"params,omitempty"`
+
+	// ETag identifies the version of the monitor returned by GetMonitor.
+	ETag string `json:"-"`
+}
+
+// Entity is an entity watched by a monitor.
+type Entity struct {
+	EntityType int                    `json:"entity_type"`
+	Params     map[string]interface{} `json:"params"`
+
+	// DiscoveredAddresses are the child contracts Hexagate discovered for a
+	// factory entity. It is set by the API.
+	DiscoveredAddresses []string `json:"discovered_addresses,omitempty"`
+}
+
+// Wallet is a wallet a monitor is scoped to.
+type Wallet struct {
+	Address string `json:"address"`
+	ChainID int    `json:"chain_id"`
+}
+
+// Rule is a notification rule of a monitor.
+type Rule struct {
+	ID                 int         `json:"id,omitempty"`
+	Name               string      `json:"name"`
+	Type               string      `json:"type"`
+	Threshold          int         `json:"threshold"`
+	NotificationPeriod *int        `json:"notification_period,omitempty"`
+	CooldownSeconds    *int        `json:"cooldown_seconds,omitempty"`
+	DedupWindowSeconds *int        `json:"dedup_window_seconds,omitempty"`
+	QuietHours         *QuietHours `json:"quiet_hours,omitempty"`
+	Categories         []int       `json:"categories"`
+	Channels           []Channel   `json:"channels"`
+
+	// categoryNames are the categories of the rule when they were configured
+	// by name. They are resolved into Categories before the rule is sent.
+	categoryNames []string
+}
+
+// QuietHours is a daily window in which a rule batches its notifications
+// instead of sending them. Alerts with a severity of at least SeverityFloor are
+// still sent immediately.
+type QuietHours struct {
+	Timezone      string `json:"timezone"`
+	Start         string `json:"start"`
+	End           string `json:"end"`
+	SeverityFloor *int   `json:"severity_floor,omitempty"`
+}
+
+// Channel is a notification channel of a rule. A channel with only an ID
+// refers to an existing channel, whose definition is kept.
+type Channel struct {
+	ID     int                    `json:"id,omitempty"`
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// IsReference reports whether the channel refers to an existing channel by
+// its ID only.
+func (c Channel) IsReference() bool {
+	return c.ID != 0 && c.Name == "" && c.Params == nil
+}
+
+// MarshalJSON encodes references to existing channels as their ID only, so
+// that the API doesn't take them for channels without a name.
+func (c Channel) MarshalJSON() ([]byte, error) {
+	if c.IsReference() {
+		return json.Marshal(map[string]int{"id": c.ID})
+	}
+	type channel Channel
+	return json.Marshal(channel(c))
 }
 
 type CreateMonitorResponse struct {
 	ID int `json:"id"`
 }
 
-func (c *HexagateClient) CreateMonitor(monitor map[string]interface{}) (*CreateMonitorResponse, error) {
+func (c *HexagateClient) CreateMonitor(ctx context.Context, monitor *Monitor) (*CreateMonitorResponse, error) {
 	body, err := json.Marshal(monitor)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/monitoring/user_monitors/", c.BaseURL), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/monitoring/user_monitors/", c.BaseURL), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	defer c.MonitorListCache.Invalidate()
+
+	// The idempotency key lets the API deduplicate retries of a create whose
+	// response was lost.
+	idempotencyKey, err := uuid.GenerateUUID()
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result CreateMonitorResponse
@@ -64,39 +392,61 @@ func (c *HexagateClient) CreateMonitor(monitor map[string]interface{}) (*CreateM
 	return &result, nil
 }
 
-func (c *HexagateClient) GetMonitor(id int) (*Monitor, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id), nil)
+func (c *HexagateClient) GetMonitor(ctx context.Context, id int) (*Monitor, error) {
+	endpoint := fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
 
-	resp, err := c.Client.Do(req)
+	etag, cached, ok := c.ETagCache.Get(endpoint)
+	if ok {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	var body []byte
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		body = cached
+	case resp.StatusCode == http.StatusOK:
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		etag = resp.Header.Get("ETag")
+		c.ETagCache.Put(endpoint, etag, body)
+	default:
+		return nil, newAPIError(resp)
 	}
 
 	var monitor Monitor
-	if err := json.NewDecoder(resp.Body).Decode(&monitor); err != nil {
+	if err := json.Unmarshal(body, &monitor); err != nil {
 		return nil, err
 	}
+	monitor.ETag = etag
 
 	return &monitor, nil
 }
 
-func (c *HexagateClient) UpdateMonitor(id int, monitor map[string]interface{}) error {
+func (c *HexagateClient) UpdateMonitor(ctx context.Context, id int, monitor *Monitor) error {
 	body, err := json.Marshal(monitor)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id), bytes.NewBuffer(body))
+	endpoint := fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id)
+	defer c.ETagCache.Invalidate(endpoint)
+	defer c.MonitorListCache.Invalidate()
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -104,64 +454,411 @@ func (c *HexagateClient) UpdateMonitor(id int, monitor map[string]interface{}) e
 	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
-func (c *HexagateClient) DeleteMonitor(id int) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id), nil)
+// PatchMonitor partially updates a monitor. Only the given top-level fields
+// are changed; a nil value clears the field.
+func (c *HexagateClient) PatchMonitor(ctx context.Context, id int, fields map[string]interface{}) error {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id)
+	defer c.ETagCache.Invalidate(endpoint)
+	defer c.MonitorListCache.Invalidate()
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+func (c *HexagateClient) DeleteMonitor(ctx context.Context, id int) error {
+	endpoint := fmt.Sprintf("%s/monitoring/user_monitors/%d", c.BaseURL, id)
+	defer c.ETagCache.Invalidate(endpoint)
+	defer c.MonitorListCache.Invalidate()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
-func (c *HexagateClient) GetAllMonitors() ([]*Monitor, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/monitoring/user_monitors/", c.BaseURL), nil)
+// GetAllMonitors lists all monitors. The returned monitors may be shared with
+// other callers through the MonitorListCache and must not be modified.
+func (c *HexagateClient) GetAllMonitors(ctx context.Context) ([]*Monitor, error) {
+	return c.MonitorListCache.Get(ctx, c.listMonitors)
+}
+
+func (c *HexagateClient) listMonitors(ctx context.Context) ([]*Monitor, error) {
+	endpoint := fmt.Sprintf("%s/monitoring/user_monitors/", c.BaseURL)
+
+	// The API returns monitors a page at a time, linking the next page until
+	// the last one.
+	var monitors []*Monitor
+	for endpoint != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Items []*Monitor `json:"items"`
+			Next  *string    `json:"next"`
+		}
+		err = func() error {
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return newAPIError(resp)
+			}
+			return json.NewDecoder(resp.Body).Decode(&response)
+		}()
+		if err != nil {
+			return nil, err
+		}
+
+		monitors = append(monitors, withoutNulls(response.Items)...)
+
+		endpoint = ""
+		if response.Next != nil && *response.Next != "" {
+			next, err := req.URL.Parse(*response.Next)
+			if err != nil {
+				return nil, fmt.Errorf("invalid next page link %q: %w", *response.Next, err)
+			}
+			endpoint = next.String()
+		}
+	}
+
+	return monitors, nil
+}
+
+// withoutNulls drops the null items of a list returned by the API, which
+// callers would otherwise dereference.
+func withoutNulls[T any](items []*T) []*T {
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+type Integration struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (c *HexagateClient) GetAllIntegrations(ctx context.Context) ([]*Integration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/integrations/", c.BaseURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response struct {
+		Items []*Integration `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return withoutNulls(response.Items), nil
+}
+
+// Category is a category of alerts.
+type Category struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *HexagateClient) GetAllCategories(ctx context.Context) ([]*Category, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/monitoring/categories/", c.BaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response struct {
+		Items []*Category `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return withoutNulls(response.Items), nil
+}
+
+type AlertStat struct {
+	MonitorID int `json:"user_monitor_id"`
+	Category  int `json:"category"`
+	Count     int `json:"count"`
+}
+
+func (c *HexagateClient) GetAlertStats(ctx context.Context, start, end time.Time, monitorID int) ([]*AlertStat, error) {
+	query := url.Values{}
+	query.Set("start_time", start.UTC().Format(time.RFC3339))
+	query.Set("end_time", end.UTC().Format(time.RFC3339))
+	if monitorID != 0 {
+		query.Set("user_monitor_id", strconv.Itoa(monitorID))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/monitoring/alerts/stats?%s", c.BaseURL, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response struct {
+		Items []*AlertStat `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return withoutNulls(response.Items), nil
+}
+
+// AlertSummary describes the current alert state of a monitor.
+type AlertSummary struct {
+	MonitorID       int    `json:"user_monitor_id"`
+	OpenCount       int    `json:"open_count"`
+	LastTriggeredAt string `json:"last_triggered_at,omitempty"`
+}
+
+func (c *HexagateClient) GetAlertSummary(ctx context.Context, monitorID int) (*AlertSummary, error) {
+	query := url.Values{}
+	query.Set("user_monitor_id", strconv.Itoa(monitorID))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/monitoring/alerts/summary?%s", c.BaseURL, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var summary AlertSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// TestNotificationRequest selects the rules of a monitor to send a test
+// notification through. All rules are tested when Rules is empty.
+type TestNotificationRequest struct {
+	Rules []string `json:"rules,omitempty"`
+}
+
+// TestNotificationResult is the outcome of delivering a test notification to
+// a channel of a rule.
+type TestNotificationResult struct {
+	Rule      string `json:"rule"`
+	ChannelID int    `json:"channel_id"`
+	Channel   string `json:"channel"`
+	Delivered bool   `json:"delivered"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (c *HexagateClient) SendTestNotification(ctx context.Context, monitorID int, test *TestNotificationRequest) ([]*TestNotificationResult, error) {
+	body, err := json.Marshal(test)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/monitoring/user_monitors/%d/test_notification", c.BaseURL, monitorID), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var response struct {
-		Items []*Monitor `json:"items"`
+		Results []*TestNotificationResult `json:"results"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, err
 	}
 
-	return response.Items, nil
+	return withoutNulls(response.Results), nil
+}
+
+type Protocol struct {
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Contracts []*ProtocolContract `json:"contracts"`
+}
+
+type ProtocolContract struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	ChainID int    `json:"chain_id"`
+}
+
+func (c *HexagateClient) SearchProtocols(ctx context.Context, name string) ([]*Protocol, error) {
+	query := url.Values{}
+	query.Set("name", name)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/protocols/?%s", c.BaseURL, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var response struct {
+		Items []*Protocol `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	protocols := withoutNulls(response.Items)
+	for _, protocol := range protocols {
+		protocol.Contracts = withoutNulls(protocol.Contracts)
+	}
+	return protocols, nil
+}
+
+// SupportedAPIVersions lists the API versions this client is known to work
+// with, newest first.
+var SupportedAPIVersions = []string{"v2"}
+
+// NegotiateAPIVersion returns the newest supported API version served below
+// root (e.g. "https://api.hexagate.com/api"), probing each in turn.
+func (c *HexagateClient) NegotiateAPIVersion(ctx context.Context, root string) (string, error) {
+	for _, version := range SupportedAPIVersions {
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/monitoring/user_monitors/", root, version), nil)
+		if err != nil {
+			return "", err
+		}
+
+		req.Header.Set("X-Hexagate-Api-Key", c.APIToken)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotFound {
+			return version, nil
+		}
+	}
+
+	return "", fmt.Errorf("none of the supported API versions (%s) are served by %s", strings.Join(SupportedAPIVersions, ", "), root)
 }
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	client := &HexagateClient{RetryWaitMin: time.Second, RetryWaitMax: 30 * time.Second}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+		if got := client.backoff(attempt, nil); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+
+	// Retry-After is honored in full, even beyond RetryWaitMax.
+	resp := &http.Response{Header: http.Header{"Retry-After": {"120"}}}
+	if got := client.backoff(0, resp); got != 2*time.Minute {
+		t.Errorf("backoff with Retry-After: 120 = %s, want %s", got, 2*time.Minute)
+	}
+
+	// With jitter, the wait is drawn from the whole range up to the computed
+	// wait, including below RetryWaitMin.
+	client.RetryJitter = true
+	var short int
+	for i := 0; i < 1000; i++ {
+		got := client.backoff(3, nil)
+		if got < 0 || got > 8*time.Second {
+			t.Fatalf("backoff(3) = %s, want between 0s and %s", got, 8*time.Second)
+		}
+		if got < client.RetryWaitMin {
+			short++
+		}
+	}
+	if short == 0 {
+		t.Errorf("backoff(3) never waited less than %s, want full jitter", client.RetryWaitMin)
+	}
+}
+
+// TestRetryBudgetPerOperation checks that the requests of an operation share
+// the retry budget, so that a request sent after an earlier one used it up is
+// not retried.
+func TestRetryBudgetPerOperation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &HexagateClient{
+		Client:       server.Client(),
+		MaxRetries:   100,
+		RetryWaitMin: 10 * time.Millisecond,
+		RetryWaitMax: 10 * time.Millisecond,
+		RetryBudget:  100 * time.Millisecond,
+	}
+	ctx, _ := trackRetries(context.Background())
+
+	get := func() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	get()
+	if n := requests.Load(); n < 2 {
+		t.Fatalf("the first request was sent %d times, want it retried", n)
+	}
+	requests.Store(0)
+	get()
+	if n := requests.Load(); n != 1 {
+		t.Errorf("the second request was sent %d times, want 1 after the operation used up the budget", n)
+	}
+}
+
+// TestRetryAfterBeyondBudget checks that a request whose Retry-After exceeds
+// the retry budget or the deadline of its context fails straight away instead
+// of waiting.
+func TestRetryAfterBeyondBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		budget  time.Duration
+		timeout time.Duration
+	}{
+		"retry budget":     {budget: time.Minute},
+		"context deadline": {timeout: time.Minute},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &HexagateClient{
+				Client:       server.Client(),
+				MaxRetries:   3,
+				RetryWaitMin: time.Second,
+				RetryWaitMax: 30 * time.Second,
+				RetryBudget:  test.budget,
+			}
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			started := time.Now()
+			resp, err := client.do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+			}
+			if elapsed := time.Since(started); elapsed > 10*time.Second {
+				t.Errorf("the request took %s, want it to fail without waiting", elapsed)
+			}
+		})
+	}
+}
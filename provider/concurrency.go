@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type ifMatchKey struct{}
+
+// withIfMatch makes the mutating requests sent with the returned context
+// conditional on the resource still having the given ETag.
+func withIfMatch(ctx context.Context, etag string) context.Context {
+	if etag == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ifMatchKey{}, etag)
+}
+
+func ifMatchFromContext(ctx context.Context) string {
+	etag, _ := ctx.Value(ifMatchKey{}).(string)
+	return etag
+}
+
+// isPreconditionFailed reports whether a conditional request was rejected
+// because the resource changed.
+func isPreconditionFailed(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// monitorModifiedDiagnostic describes a monitor that was changed outside of
+// Terraform since it was last refreshed.
+func monitorModifiedDiagnostic(id int, detail string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Monitor Modified Outside Terraform",
+		fmt.Sprintf("Drift detected: monitor ID %d was modified since Terraform last refreshed it%s. "+
+			"Refresh and re-plan to review the changes before applying, so they are not overwritten.", id, detail),
+	)
+}
+
+// checkMonitorUnmodified fetches the monitor and fails if its updated_at
+// differs from the one recorded in state. The returned context makes
+// subsequent writes conditional on the fetched version, closing the window
+// between the check and the write.
+func checkMonitorUnmodified(ctx context.Context, client MonitorAPI, id int, updatedAt timetypes.RFC3339) (context.Context, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if updatedAt.IsNull() || updatedAt.IsUnknown() {
+		return ctx, diags
+	}
+	recorded, timeDiags := updatedAt.ValueRFC3339Time()
+	if timeDiags.HasError() {
+		return ctx, diags
+	}
+
+	current, err := client.GetMonitor(ctx, id)
+	if err != nil {
+		// Let the write itself report the error, e.g. a missing monitor.
+		return ctx, diags
+	}
+
+	// Refreshes keep the recorded timestamp when it is equal to the second,
+	// so compare at that precision
+	if currentUpdatedAt, err := parseTimestamp(current.UpdatedAt); err == nil && !currentUpdatedAt.Truncate(time.Second).Equal(recorded.Truncate(time.Second)) {
+		diags.Append(monitorModifiedDiagnostic(id, fmt.Sprintf(" (updated at %s, last refreshed version updated at %s)", current.UpdatedAt, updatedAt.ValueString())))
+		return ctx, diags
+	}
+
+	return withIfMatch(ctx, current.ETag), diags
+}
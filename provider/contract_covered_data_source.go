@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ContractCoveredDataSource{}
+
+func NewContractCoveredDataSource() datasource.DataSource {
+	return &ContractCoveredDataSource{}
+}
+
+// ContractCoveredDataSource reports whether an address is watched by at least
+// one enabled monitor, for assertions in check blocks.
+type ContractCoveredDataSource struct {
+	client *Client
+}
+
+// ContractCoveredDataSourceModel describes the data source data model.
+type ContractCoveredDataSourceModel struct {
+	Address    types.String `tfsdk:"address"`
+	ChainID    types.Int64  `tfsdk:"chain_id"`
+	Covered    types.Bool   `tfsdk:"covered"`
+	MonitorIDs types.List   `tfsdk:"monitor_ids"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+func (d *ContractCoveredDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ContractCoveredDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contract_covered"
+}
+
+func (d *ContractCoveredDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports whether an address is watched by at least one enabled monitor, for assertions in check blocks.",
+		Attributes: map[string]schema.Attribute{
+			"address": schema.StringAttribute{
+				Required:    true,
+				Description: "The address of the contract.",
+				Validators: []validator.String{
+					addressValue(),
+				},
+			},
+			"chain_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of the chain the contract is on. When not set, monitors of the address on any chain count.",
+			},
+			"covered": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether at least one enabled monitor watches the address.",
+			},
+			"monitor_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The IDs of the enabled monitors that watch the address.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *ContractCoveredDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state ContractCoveredDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Contract Coverage",
+			fmt.Sprintf("Could not list monitors: %s", err),
+		)
+		return
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i].ID < monitors[j].ID })
+
+	chainID := int64(-1)
+	if !state.ChainID.IsNull() {
+		chainID = state.ChainID.ValueInt64()
+	}
+
+	ids := []string{}
+	for _, monitor := range monitors {
+		if !monitor.Disabled && watchesAddress(monitor, state.Address.ValueString(), chainID) {
+			ids = append(ids, strconv.Itoa(monitor.ID))
+		}
+	}
+
+	state.Covered = types.BoolValue(len(ids) > 0)
+	state.MonitorIDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// watchesAddress reports whether a monitor watches an address on a chain, or
+// on any chain when chainID is negative: as the address of an entity or
+// wallet, as a factory, or as a child contract discovered for a factory.
+// Addresses are compared case-insensitively.
+func watchesAddress(monitor *Monitor, address string, chainID int64) bool {
+	matches := func(candidate string, candidateChainID int64) bool {
+		return strings.EqualFold(candidate, address) && (chainID < 0 || candidateChainID == chainID)
+	}
+
+	for _, wallet := range monitor.Wallets {
+		if matches(wallet.Address, int64(wallet.ChainID)) {
+			return true
+		}
+	}
+
+	for _, entity := range monitor.Entities {
+		entityChainID := int64(-1)
+		if value, ok := entity.Params["chain_id"].(float64); ok {
+			entityChainID = int64(value)
+		}
+		for _, key := range []string{"address", "factory_address"} {
+			if value, ok := entity.Params[key].(string); ok && matches(value, entityChainID) {
+				return true
+			}
+		}
+		for _, discovered := range entity.DiscoveredAddresses {
+			if matches(discovered, entityChainID) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// resolveAPIToken returns the API token from whichever of api_token,
+// api_token_file or api_token_command is configured. Exactly one of them, or
+// OAuth2 client credentials, must be set. When OAuth2 is configured the
+// returned token is empty.
+func resolveAPIToken(ctx context.Context, config HexagateProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := 0
+	for _, isNull := range []bool{config.APIToken.IsNull(), config.APITokenFile.IsNull(), config.APITokenCommand.IsNull(), config.OAuthClientID.IsNull()} {
+		if !isNull {
+			configured++
+		}
+	}
+
+	if configured == 0 {
+		diags.AddError(
+			"Missing API Token Configuration",
+			"While configuring the provider, the API token was not found. "+
+				"Please configure one of the api_token, api_token_file, api_token_command or oauth_client_id attributes in the provider configuration block.",
+		)
+		return "", diags
+	}
+	if configured > 1 {
+		diags.AddError(
+			"Conflicting API Token Configuration",
+			"Only one of the api_token, api_token_file, api_token_command and oauth_client_id attributes may be configured.",
+		)
+		return "", diags
+	}
+
+	switch {
+	case !config.OAuthClientID.IsNull():
+		if config.OAuthClientSecret.IsNull() {
+			diags.AddAttributeError(
+				path.Root("oauth_client_secret"),
+				"Missing OAuth2 Configuration",
+				"The oauth_client_secret attribute must be configured together with oauth_client_id.",
+			)
+		}
+		return "", diags
+
+	case !config.APIToken.IsNull():
+		return config.APIToken.ValueString(), diags
+
+	case !config.APITokenFile.IsNull():
+		contents, err := os.ReadFile(config.APITokenFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("api_token_file"),
+				"Unable to Read API Token File",
+				fmt.Sprintf("Could not read the API token file: %s", err),
+			)
+			return "", diags
+		}
+		token := strings.TrimSpace(string(contents))
+		if token == "" {
+			diags.AddAttributeError(
+				path.Root("api_token_file"),
+				"Unable to Read API Token File",
+				"The API token file is empty.",
+			)
+		}
+		return token, diags
+
+	default:
+		var args []string
+		diags.Append(config.APITokenCommand.ElementsAs(ctx, &args, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		if len(args) == 0 {
+			diags.AddAttributeError(
+				path.Root("api_token_command"),
+				"Invalid Credential Helper Configuration",
+				"The api_token_command attribute must contain at least the program to run.",
+			)
+			return "", diags
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			diags.AddAttributeError(
+				path.Root("api_token_command"),
+				"Credential Helper Failed",
+				fmt.Sprintf("The credential helper %q failed: %s\n\n%s", args[0], err, strings.TrimSpace(stderr.String())),
+			)
+			return "", diags
+		}
+		token := strings.TrimSpace(stdout.String())
+		if token == "" {
+			diags.AddAttributeError(
+				path.Root("api_token_command"),
+				"Credential Helper Failed",
+				fmt.Sprintf("The credential helper %q did not print a token.", args[0]),
+			)
+		}
+		return token, diags
+	}
+}
+
+// TokenSource provides bearer tokens for API requests.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TemporaryTokenSource mints short-lived tokens that are handed out to
+// configurations, separately from the tokens of the provider's own requests.
+type TemporaryTokenSource interface {
+	MintToken(ctx context.Context, scopes []string) (token string, expires time.Time, err error)
+}
+
+// clientCredentialsTokenSource exchanges an OAuth2 client ID and secret for
+// bearer tokens, refreshing them shortly before they expire. Token requests
+// send the same User-Agent and extra headers as API requests, so that
+// gateways in front of the token endpoint see the same client.
+type clientCredentialsTokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	client       *http.Client
+	userAgent    string
+	headers      map[string]string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed.
+const tokenExpiryDelta = 30 * time.Second
+
+func (s *clientCredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expires.IsZero() || time.Now().Add(tokenExpiryDelta).Before(s.expires)) {
+		return s.token, nil
+	}
+
+	token, expires, err := s.exchange(ctx, s.scopes)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expires = expires
+	return s.token, nil
+}
+
+// MintToken implements TemporaryTokenSource. Every call exchanges the client
+// credentials for a new token, which isn't shared with the provider's
+// requests. Without scopes, the token gets the provider's scopes.
+func (s *clientCredentialsTokenSource) MintToken(ctx context.Context, scopes []string) (string, time.Time, error) {
+	if len(scopes) == 0 {
+		scopes = s.scopes
+	}
+	return s.exchange(ctx, scopes)
+}
+
+// exchange requests a token with the given scopes from the token endpoint. The
+// expiry is zero when the endpoint doesn't report one.
+func (s *clientCredentialsTokenSource) exchange(ctx context.Context, scopes []string) (string, time.Time, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+	for name, value := range s.headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("unexpected status code from token endpoint: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, err
+	}
+	if result.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("token endpoint did not return an access token")
+	}
+
+	var expires time.Time
+	if result.ExpiresIn > 0 {
+		expires = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return result.AccessToken, expires, nil
+}
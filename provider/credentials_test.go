@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCredentialsTokenSourceHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	source := &clientCredentialsTokenSource{
+		tokenURL:     server.URL,
+		clientID:     "id",
+		clientSecret: "secret",
+		client:       server.Client(),
+		userAgent:    "terraform-provider-hexagate/test",
+		headers: map[string]string{
+			"X-Gateway-Key": "key",
+			"Content-Type":  "text/plain",
+		},
+	}
+
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		"User-Agent":    "terraform-provider-hexagate/test",
+		"X-Gateway-Key": "key",
+		// Extra headers don't override the headers of the token request.
+		"Content-Type": "application/x-www-form-urlencoded",
+	} {
+		if value := got.Get(name); value != want {
+			t.Errorf("%s header = %q, want %q", name, value, want)
+		}
+	}
+	if got.Get("Authorization") == "" {
+		t.Error("Authorization header not sent")
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// opaqueAttributes are attributes whose value the API sees as nested
+// structure (JSON strings) or which cannot be indexed (sets). Field errors
+// below them are reported on the attribute itself.
+var opaqueAttributes = map[string]bool{
+	"params":   true,
+	"entities": true,
+	"channels": true,
+}
+
+// appendAPIErrorDiagnostics adds an error diagnostic for err. Field errors
+// reported by the API are additionally attached to the matching attribute
+// path when the field's root is one of the given attributes.
+func appendAPIErrorDiagnostics(diags *diag.Diagnostics, summary, detail string, err error, attributes ...string) {
+	diags.AddError(summary, fmt.Sprintf("%s: %s", detail, err))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return
+	}
+
+	for _, fe := range apiErr.FieldErrors {
+		p, ok := fieldPath(fe.Field, attributes)
+		if !ok {
+			continue
+		}
+		diags.AddAttributeError(p, summary, fmt.Sprintf("The Hexagate API rejected this value: %s", fe.Message))
+	}
+}
+
+// fieldPath converts a dot separated API field into an attribute path.
+func fieldPath(field string, attributes []string) (path.Path, bool) {
+	parts := strings.Split(field, ".")
+	if len(parts) == 0 || !slices.Contains(attributes, parts[0]) {
+		return path.Empty(), false
+	}
+
+	p := path.Root(parts[0])
+	if opaqueAttributes[parts[0]] {
+		return p, true
+	}
+	for _, part := range parts[1:] {
+		if index, err := strconv.Atoi(part); err == nil {
+			p = p.AtListIndex(index)
+			continue
+		}
+		p = p.AtName(part)
+		if opaqueAttributes[part] {
+			break
+		}
+	}
+
+	return p, true
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/smartcontracts/terraform-provider-hexagate/internal/jsonutil"
+)
+
+var _ function.Function = &EntityParamsFunction{}
+
+func NewEntityParamsFunction() function.Function {
+	return &EntityParamsFunction{}
+}
+
+// EntityParamsFunction builds the params of an entity from its chain, address
+// and any further params, instead of writing them as JSON by hand.
+type EntityParamsFunction struct{}
+
+func (f *EntityParamsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "entity_params"
+}
+
+func (f *EntityParamsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the params of a monitor entity",
+		Description: "Returns the JSON params of an entity: the address and chain_id, merged with the extra params, " +
+			"for use as the params of the entities of hexagate_monitor. " +
+			"The chain is given by name, as for chain_id, or by its ID, and the address is checked like the address attributes of hexagate_monitor.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "chain",
+				Description: "The name or ID of the chain the address is on",
+			},
+			function.StringParameter{
+				Name:        "address",
+				Description: "The address of the entity",
+			},
+			function.DynamicParameter{
+				Name:           "extra",
+				Description:    "An object or map of further params, or null",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EntityParamsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var chain, address string
+	var extra types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &chain, &address, &extra)
+	if resp.Error != nil {
+		return
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(chain), 10, 64)
+	if err != nil {
+		var ok bool
+		if id, ok = chainID(chain); !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown chain %q. Known chains are: %s", chain, strings.Join(chainNames(), ", ")))
+			return
+		}
+	} else if id <= 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid chain ID %d: chain IDs are positive.", id))
+		return
+	}
+
+	if !isValidAddress(address) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid address %q: it must be a 0x-prefixed address of 40 hex characters, with a valid EIP-55 checksum if in mixed case.", address))
+		return
+	}
+
+	params := map[string]interface{}{}
+	if !extra.IsNull() && !extra.IsUnderlyingValueNull() {
+		value, err := extra.UnderlyingValue().ToTerraformValue(ctx)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid params: %s", err))
+			return
+		}
+		if !value.Type().Is(tftypes.Object{}) && !value.Type().Is(tftypes.Map{}) {
+			resp.Error = function.NewArgumentFuncError(2, "Invalid params: extra must be an object or map.")
+			return
+		}
+		decoded, err := terraformValueToJSON(value)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid params: %s", err))
+			return
+		}
+		params = decoded.(map[string]interface{})
+	}
+
+	for _, key := range []string{"address", "chain_id"} {
+		if _, ok := params[key]; ok {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid params: %s is set by the chain and address arguments and can't be part of extra.", key))
+			return
+		}
+	}
+	params["address"] = address
+	params["chain_id"] = id
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not encode the params: %s", err))
+		return
+	}
+	normalized, err := jsonutil.Normalize(string(encoded))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not encode the params: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+// terraformValueToJSON converts a known Terraform value into the value
+// json.Marshal encodes as the equivalent JSON, as jsonencode does.
+func terraformValueToJSON(value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Is(tftypes.Number):
+		number := new(big.Float)
+		if err := value.As(&number); err != nil {
+			return nil, err
+		}
+		if number.IsInt() {
+			return json.Number(number.Text('f', 0)), nil
+		}
+		return json.Number(number.Text('g', -1)), nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(elements))
+		for i, element := range elements {
+			item, err := terraformValueToJSON(element)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case value.Type().Is(tftypes.Map{}), value.Type().Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{}, len(attributes))
+		for key, attribute := range attributes {
+			item, err := terraformValueToJSON(attribute)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = item
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", value.Type())
+	}
+}
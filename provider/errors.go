@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error response body is read.
+const maxErrorBodySize = 64 * 1024
+
+// APIError is returned when the API responds with an unexpected status code.
+type APIError struct {
+	StatusCode  int
+	Message     string
+	FieldErrors []FieldError
+	RequestID   string
+}
+
+// FieldError is a validation error the API reported for a single field of
+// the request. Field is the dot separated path of the field, with list
+// indices as numbers, e.g. "monitor_rules.0.threshold".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "unexpected status code: %d", e.StatusCode)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	for _, fe := range e.FieldErrors {
+		fmt.Fprintf(&b, "\n  %s: %s", fe.Field, fe.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "\n(request ID: %s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// isNotFound reports whether err is a 404 response from the API.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// RequestError is returned when a request fails without a response. It
+// carries the ID the request was sent with, for correlation with Hexagate's
+// logs.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	if e.RequestID == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (request ID: %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from an unexpected response, parsing the
+// error body when it is JSON. Both {"message": ..., "errors": [...]} bodies
+// and FastAPI style {"detail": ...} bodies are understood. When the API
+// doesn't report a request ID, the ID the request was sent with is used.
+func newAPIError(resp *http.Response) error {
+	apiErr := parseAPIError(resp)
+	if apiErr.RequestID == "" && resp.Request != nil {
+		apiErr.RequestID = resp.Request.Header.Get("X-Request-Id")
+	}
+	return apiErr
+}
+
+func parseAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(body) == 0 {
+		return apiErr
+	}
+
+	var parsed struct {
+		Message   string          `json:"message"`
+		Error     string          `json:"error"`
+		Detail    json.RawMessage `json:"detail"`
+		RequestID string          `json:"request_id"`
+		Errors    []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.Message = parsed.Message
+	if apiErr.Message == "" {
+		apiErr.Message = parsed.Error
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = parsed.RequestID
+	}
+	for _, fe := range parsed.Errors {
+		apiErr.FieldErrors = append(apiErr.FieldErrors, FieldError{Field: fe.Field, Message: fe.Message})
+	}
+
+	if len(parsed.Detail) > 0 {
+		var detail string
+		var details []struct {
+			Loc []interface{} `json:"loc"`
+			Msg string        `json:"msg"`
+		}
+		if err := json.Unmarshal(parsed.Detail, &detail); err == nil {
+			if apiErr.Message == "" {
+				apiErr.Message = detail
+			}
+		} else if err := json.Unmarshal(parsed.Detail, &details); err == nil {
+			for _, d := range details {
+				apiErr.FieldErrors = append(apiErr.FieldErrors, FieldError{Field: locToField(d.Loc), Message: d.Msg})
+			}
+		}
+	}
+
+	return apiErr
+}
+
+// locToField converts a FastAPI error location such as
+// ["body", "monitor_rules", 0, "threshold"] into a dot separated field path.
+func locToField(loc []interface{}) string {
+	parts := make([]string, 0, len(loc))
+	for i, l := range loc {
+		switch v := l.(type) {
+		case string:
+			if i == 0 && (v == "body" || v == "query" || v == "path") {
+				continue
+			}
+			parts = append(parts, v)
+		case float64:
+			parts = append(parts, strconv.Itoa(int(v)))
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+var _ MonitorAPI = &FakeMonitorAPI{}
+
+// FakeMonitorAPI is an in-memory MonitorAPI for exercising resource logic
+// without a live API. Like the API, it assigns IDs to new monitors, rules and
+// channels, keeps the IDs of existing ones on update, and returns a 404
+// APIError for monitors that do not exist.
+//
+// Monitors are copied on the way in and out, so callers cannot modify the
+// stored state through returned values.
+type FakeMonitorAPI struct {
+	mu       sync.Mutex
+	monitors map[int]*Monitor
+	nextID   int
+
+	// Integrations, Categories, AlertStats, AlertSummaries and Protocols are
+	// returned by the read-only endpoints. They may be set directly before use.
+	Integrations   []*Integration
+	Categories     []*Category
+	AlertStats     []*AlertStat
+	AlertSummaries []*AlertSummary
+	Protocols      []*Protocol
+
+	// FailingChannels are the IDs of channels that test notifications fail
+	// to be delivered to.
+	FailingChannels map[int]bool
+}
+
+func NewFakeMonitorAPI() *FakeMonitorAPI {
+	return &FakeMonitorAPI{
+		monitors: map[int]*Monitor{},
+		nextID:   1,
+	}
+}
+
+// WithRequestOptions validates the options like HexagateClient does, but
+// returns the receiver since request options do not affect the fake.
+func (f *FakeMonitorAPI) WithRequestOptions(opts *RequestOptionsModel) (MonitorAPI, diag.Diagnostics) {
+	_, diags := (&HexagateClient{Client: &http.Client{}}).WithRequestOptions(opts)
+	return f, diags
+}
+
+func (f *FakeMonitorAPI) CreateMonitor(_ context.Context, monitor *Monitor) (*CreateMonitorResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := copyMonitor(monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	stored.ID = f.allocateID()
+	now := time.Now().UTC().Format(time.RFC3339)
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	f.assignRuleIDs(stored, nil)
+	f.monitors[stored.ID] = stored
+
+	return &CreateMonitorResponse{ID: stored.ID}, nil
+}
+
+func (f *FakeMonitorAPI) GetMonitor(_ context.Context, id int) (*Monitor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	monitor, ok := f.monitors[id]
+	if !ok {
+		return nil, monitorNotFound(id)
+	}
+	return copyMonitor(monitor)
+}
+
+func (f *FakeMonitorAPI) UpdateMonitor(_ context.Context, id int, monitor *Monitor) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.monitors[id]
+	if !ok {
+		return monitorNotFound(id)
+	}
+
+	stored, err := copyMonitor(monitor)
+	if err != nil {
+		return err
+	}
+
+	stored.ID = id
+	stored.CreatedAt = existing.CreatedAt
+	stored.CreatedBy = existing.CreatedBy
+	stored.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	f.assignRuleIDs(stored, existing)
+	f.monitors[id] = stored
+
+	return nil
+}
+
+func (f *FakeMonitorAPI) PatchMonitor(ctx context.Context, id int, fields map[string]interface{}) error {
+	monitor, err := f.GetMonitor(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(monitor)
+	if err != nil {
+		return err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return err
+	}
+	for field, value := range fields {
+		merged[field] = value
+	}
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	var patched Monitor
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return err
+	}
+
+	return f.UpdateMonitor(ctx, id, &patched)
+}
+
+func (f *FakeMonitorAPI) DeleteMonitor(_ context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.monitors[id]; !ok {
+		return monitorNotFound(id)
+	}
+	delete(f.monitors, id)
+	return nil
+}
+
+func (f *FakeMonitorAPI) GetAllMonitors(_ context.Context) ([]*Monitor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]int, 0, len(f.monitors))
+	for id := range f.monitors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	monitors := make([]*Monitor, 0, len(ids))
+	for _, id := range ids {
+		monitor, err := copyMonitor(f.monitors[id])
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors, nil
+}
+
+func (f *FakeMonitorAPI) GetAllIntegrations(_ context.Context) ([]*Integration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*Integration(nil), f.Integrations...), nil
+}
+
+func (f *FakeMonitorAPI) GetAllCategories(_ context.Context) ([]*Category, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*Category(nil), f.Categories...), nil
+}
+
+func (f *FakeMonitorAPI) GetAlertStats(_ context.Context, _, _ time.Time, monitorID int) ([]*AlertStat, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var stats []*AlertStat
+	for _, stat := range f.AlertStats {
+		if monitorID == 0 || stat.MonitorID == monitorID {
+			stats = append(stats, stat)
+		}
+	}
+	return stats, nil
+}
+
+// GetAlertSummary returns the summary of the monitor in AlertSummaries, or a
+// summary without alerts.
+func (f *FakeMonitorAPI) GetAlertSummary(_ context.Context, monitorID int) (*AlertSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, summary := range f.AlertSummaries {
+		if summary.MonitorID == monitorID {
+			copied := *summary
+			return &copied, nil
+		}
+	}
+	return &AlertSummary{MonitorID: monitorID}, nil
+}
+
+// SendTestNotification reports every channel of the selected rules as
+// delivered, unless the channel's ID is in FailingChannels.
+func (f *FakeMonitorAPI) SendTestNotification(_ context.Context, monitorID int, test *TestNotificationRequest) ([]*TestNotificationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	monitor, ok := f.monitors[monitorID]
+	if !ok {
+		return nil, monitorNotFound(monitorID)
+	}
+
+	selected := map[string]bool{}
+	for _, name := range test.Rules {
+		selected[name] = true
+	}
+
+	results := []*TestNotificationResult{}
+	for _, rule := range monitor.MonitorRules {
+		if len(selected) > 0 && !selected[rule.Name] {
+			continue
+		}
+		delete(selected, rule.Name)
+		for _, channel := range rule.Channels {
+			result := &TestNotificationResult{Rule: rule.Name, ChannelID: channel.ID, Channel: channel.Name, Delivered: true}
+			if f.FailingChannels[channel.ID] {
+				result.Delivered = false
+				result.Error = "delivery failed"
+			}
+			results = append(results, result)
+		}
+	}
+	for name := range selected {
+		return nil, &APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("monitor %d has no rule %q", monitorID, name),
+		}
+	}
+	return results, nil
+}
+
+func (f *FakeMonitorAPI) SearchProtocols(_ context.Context, name string) ([]*Protocol, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var protocols []*Protocol
+	for _, protocol := range f.Protocols {
+		if strings.Contains(strings.ToLower(protocol.Name), strings.ToLower(name)) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols, nil
+}
+
+// allocateID returns an ID that is unique across monitors, rules and channels.
+func (f *FakeMonitorAPI) allocateID() int {
+	id := f.nextID
+	f.nextID++
+	return id
+}
+
+// assignRuleIDs gives new rules and channels an ID. Rules and channels that
+// reference an ID of the existing monitor keep it; unknown IDs are replaced,
+// as the API does. Channels given by their ID only take the definition of the
+// channel with the ID in any monitor.
+func (f *FakeMonitorAPI) assignRuleIDs(monitor, existing *Monitor) {
+	known := map[int]bool{}
+	if existing != nil {
+		for _, rule := range existing.MonitorRules {
+			known[rule.ID] = true
+			for _, channel := range rule.Channels {
+				known[channel.ID] = true
+			}
+		}
+	}
+
+	for i := range monitor.MonitorRules {
+		rule := &monitor.MonitorRules[i]
+		if rule.ID == 0 || !known[rule.ID] {
+			rule.ID = f.allocateID()
+		}
+		for j := range rule.Channels {
+			channel := &rule.Channels[j]
+			if channel.IsReference() {
+				if referenced, ok := f.channel(channel.ID); ok {
+					*channel = referenced
+					continue
+				}
+			}
+			if channel.ID == 0 || !known[channel.ID] {
+				channel.ID = f.allocateID()
+			}
+		}
+	}
+}
+
+// channel returns the channel with the given ID in any monitor.
+func (f *FakeMonitorAPI) channel(id int) (Channel, bool) {
+	for _, monitor := range f.monitors {
+		for _, rule := range monitor.MonitorRules {
+			for _, channel := range rule.Channels {
+				if channel.ID == id {
+					return channel, true
+				}
+			}
+		}
+	}
+	return Channel{}, false
+}
+
+func monitorNotFound(id int) error {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("monitor %d not found", id),
+	}
+}
+
+// copyMonitor deep copies a monitor by round-tripping it through JSON, which
+// also normalizes params the way the API does.
+func copyMonitor(monitor *Monitor) (*Monitor, error) {
+	data, err := json.Marshal(monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied Monitor
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
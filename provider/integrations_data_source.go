@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &IntegrationsDataSource{}
+
+func NewIntegrationsDataSource() datasource.DataSource {
+	return &IntegrationsDataSource{}
+}
+
+type IntegrationsDataSource struct {
+	client *Client
+}
+
+// IntegrationsDataSourceModel describes the data source data model.
+type IntegrationsDataSourceModel struct {
+	Type         types.String       `tfsdk:"type"`
+	Integrations []IntegrationModel `tfsdk:"integrations"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+// IntegrationModel describes a single configured integration.
+type IntegrationModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *IntegrationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IntegrationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integrations"
+}
+
+func (d *IntegrationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the third-party integrations (Slack, PagerDuty, webhooks, ...) configured in Hexagate.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return integrations of this type.",
+			},
+			"integrations": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The configured integrations.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The ID of the integration.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the integration.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the integration.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *IntegrationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state IntegrationsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	integrations, err := client.GetAllIntegrations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Integrations",
+			fmt.Sprintf("Could not list integrations: %s", err),
+		)
+		return
+	}
+
+	state.Integrations = make([]IntegrationModel, 0, len(integrations))
+	for _, integration := range integrations {
+		if !state.Type.IsNull() && integration.Type != state.Type.ValueString() {
+			continue
+		}
+		state.Integrations = append(state.Integrations, IntegrationModel{
+			ID:   types.Int64Value(int64(integration.ID)),
+			Name: types.StringValue(integration.Name),
+			Type: types.StringValue(integration.Type),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
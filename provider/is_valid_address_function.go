@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IsValidAddressFunction{}
+
+func NewIsValidAddressFunction() function.Function {
+	return &IsValidAddressFunction{}
+}
+
+// IsValidAddressFunction checks an EVM address the same way the address
+// attributes of the monitor resource do, for use in variable validation.
+type IsValidAddressFunction struct{}
+
+func (f *IsValidAddressFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_address"
+}
+
+func (f *IsValidAddressFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid EVM address",
+		Description: "Returns true when the string is a 0x-prefixed address of 40 hex characters. " +
+			"Addresses in mixed case must also have a valid EIP-55 checksum, so that mistyped addresses are rejected.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "address",
+				Description: "The address to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsValidAddressFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var address string
+	resp.Error = req.Arguments.Get(ctx, &address)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidAddress(address))
+}
+
+// isValidAddress reports whether a string is an address that addressValue
+// accepts.
+func isValidAddress(address string) bool {
+	if !addressPattern.MatchString(address) {
+		return false
+	}
+	digits := address[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return true
+	}
+	return checksumAddress(address) == address
+}
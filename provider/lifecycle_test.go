@@ -0,0 +1,147 @@
+package provider_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/smartcontracts/terraform-provider-hexagate/internal/vcr"
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// The lifecycle tests replay the API interactions recorded in the cassettes
+// in testdata/cassettes, so that they run without an API. Set
+// HEXAGATE_VCR_RECORD to record the cassettes again, against the mock server
+// or, with TF_ACC, against the real API:
+//
+//	HEXAGATE_VCR_RECORD=1 go test ./provider -run TestLifecycle
+//
+// Requests are matched by method, path, query and body, so the monitors are
+// named without the random suffix of the acceptance tests.
+
+// newCassette returns the provider configuration and options that replay the
+// cassette of the test, or record it when HEXAGATE_VCR_RECORD is set. When
+// replaying, the test fails unless every recorded request was sent.
+func newCassette(t *testing.T) (string, provider.Option) {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+
+	if os.Getenv("HEXAGATE_VCR_RECORD") == "" {
+		recorder, err := vcr.New(path, vcr.ModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			for _, interaction := range recorder.Unused() {
+				t.Errorf("recorded request not sent: %s %s %s", interaction.Request.Method, interaction.Request.Path, interaction.Request.Body)
+			}
+		})
+		return `{"api_token": "tf-acc-test-token", "api_url": "https://api.hexagate.invalid/api/v2"}`, provider.WithMiddleware(recorder.Middleware)
+	}
+
+	recorder, err := vcr.New(path, vcr.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Registered before the monitors' cleanups, so that it runs after them
+	// and records their deletion.
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("saving the cassette: %s", err)
+		}
+	})
+	return accProviderConfig(t), provider.WithMiddleware(recorder.Middleware)
+}
+
+func TestLifecycleCreate(t *testing.T) {
+	providerConfig, middleware := newCassette(t)
+	tf := newAccTerraform(t, providerConfig, middleware)
+
+	tf.apply(accMonitorConfig(accTestPrefix+"create", 1, "created", 50))
+	if id := stringValue(t, tf.attribute("id")); id == "" {
+		t.Error("the created monitor has no ID")
+	}
+	if got := stringValue(t, tf.attribute("description")); got != "created" {
+		t.Errorf("description = %q, want %q", got, "created")
+	}
+	if got := int64Value(t, tf.attribute("rule_ids", tftypes.ElementKeyString("critical"))); got == 0 {
+		t.Error("the rule has no ID")
+	}
+}
+
+func TestLifecycleUpdate(t *testing.T) {
+	providerConfig, middleware := newCassette(t)
+	tf := newAccTerraform(t, providerConfig, middleware)
+	name := accTestPrefix + "update"
+
+	tf.apply(accMonitorConfig(name, 1, "created", 50))
+	id := stringValue(t, tf.attribute("id"))
+	ruleID := int64Value(t, tf.attribute("rule_ids", tftypes.ElementKeyString("critical")))
+
+	if plan := tf.apply(accMonitorConfig(name, 1, "updated", 90)); plan.replace {
+		t.Fatal("updating the description and threshold replaces the monitor")
+	}
+	if got := stringValue(t, tf.attribute("id")); got != id {
+		t.Errorf("ID after the update = %s, want %s", got, id)
+	}
+	if got := int64Value(t, tf.attribute("rule_ids", tftypes.ElementKeyString("critical"))); got != ruleID {
+		t.Errorf("rule ID after the update = %d, want %d", got, ruleID)
+	}
+	if got := stringValue(t, tf.attribute("description")); got != "updated" {
+		t.Errorf("description after the update = %q, want %q", got, "updated")
+	}
+}
+
+func TestLifecycleReplace(t *testing.T) {
+	providerConfig, middleware := newCassette(t)
+	tf := newAccTerraform(t, providerConfig, middleware)
+	name := accTestPrefix + "replace"
+
+	tf.apply(accMonitorConfig(name, 1, "", 50))
+	id := stringValue(t, tf.attribute("id"))
+
+	if plan := tf.apply(accMonitorConfig(name, 2, "", 50)); !plan.replace {
+		t.Fatal("changing monitor_id doesn't replace the monitor")
+	}
+	if got := stringValue(t, tf.attribute("id")); got == id {
+		t.Errorf("ID after replacing the monitor = %s, want a new one", got)
+	}
+	if got := int64Value(t, tf.attribute("monitor_id")); got != 2 {
+		t.Errorf("monitor_id after replacing the monitor = %d, want 2", got)
+	}
+}
+
+func TestLifecycleImport(t *testing.T) {
+	providerConfig, middleware := newCassette(t)
+	tf := newAccTerraform(t, providerConfig, middleware)
+	name := accTestPrefix + "import"
+	config := accMonitorConfig(name, 1, "imported", 50)
+
+	tf.apply(config)
+	for _, importID := range []string{stringValue(t, tf.attribute("id")), "name=" + name} {
+		imported := newAccTerraform(t, providerConfig, middleware)
+		if err := imported.importState(importID); err != nil {
+			t.Fatalf("importing %s: %s", importID, err)
+		}
+		if !imported.state.Equal(tf.state) {
+			t.Errorf("the state imported with %s differs from the state of the created monitor", importID)
+		}
+		imported.assertNoChanges(config)
+		imported.forget()
+	}
+}
+
+func TestLifecycleDelete(t *testing.T) {
+	providerConfig, middleware := newCassette(t)
+	tf := newAccTerraform(t, providerConfig, middleware)
+
+	tf.apply(accMonitorConfig(accTestPrefix+"delete", 1, "", 50))
+	id := stringValue(t, tf.attribute("id"))
+	if err := tf.destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newAccTerraform(t, providerConfig, middleware).importState(id); err == nil {
+		t.Errorf("the deleted monitor %s still exists", id)
+	}
+}
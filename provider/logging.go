@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***"
+
+// sensitiveHeaders are never logged in the clear.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":      true,
+	"X-Hexagate-Api-Key": true,
+	"Cookie":             true,
+	"Set-Cookie":         true,
+}
+
+// sensitiveKeys are JSON object keys whose values are redacted wherever they
+// appear in a logged body.
+var sensitiveKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"api_key":       true,
+	"api_token":     true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// traceLoggingEnabled reports whether Terraform was started with trace
+// logging, in which case request and response bodies are logged as well.
+func traceLoggingEnabled() bool {
+	for _, name := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		if strings.EqualFold(os.Getenv(name), "TRACE") {
+			return true
+		}
+	}
+	return false
+}
+
+// logRequest logs a request attempt. The values of the extra headers the
+// provider is configured with are redacted, since they typically authenticate
+// with a gateway in front of the API.
+func logRequest(ctx context.Context, req *http.Request, attempt int, extraHeaders map[string]string) {
+	fields := map[string]interface{}{
+		"http_method":  req.Method,
+		"http_url":     req.URL.String(),
+		"http_attempt": attempt + 1,
+		"http_headers": redactHeaders(req.Header, extraHeaders),
+	}
+	if traceLoggingEnabled() && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			fields["http_body"] = redactBody(data)
+		}
+	}
+	tflog.Debug(ctx, "Sending Hexagate API request", fields)
+}
+
+func logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	fields := map[string]interface{}{
+		"http_method":      req.Method,
+		"http_url":         req.URL.String(),
+		"http_duration_ms": elapsed.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Hexagate API request failed", fields)
+		return
+	}
+
+	fields["http_status"] = resp.StatusCode
+	fields["http_headers"] = redactHeaders(resp.Header, nil)
+	if traceLoggingEnabled() && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr == nil {
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			fields["http_body"] = redactBody(data)
+		} else {
+			// Replay the error after the data read so far, so that callers
+			// still see it.
+			resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), errReader{readErr}))
+		}
+	}
+	tflog.Debug(ctx, "Received Hexagate API response", fields)
+}
+
+// redactHeaders returns the headers to log, with the values of the
+// sensitiveHeaders and of the additionally given headers redacted.
+func redactHeaders(header http.Header, additional map[string]string) map[string]string {
+	sensitive := sensitiveHeaders
+	if len(additional) > 0 {
+		sensitive = make(map[string]bool, len(sensitiveHeaders)+len(additional))
+		for name := range sensitiveHeaders {
+			sensitive[name] = true
+		}
+		for name := range additional {
+			sensitive[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitive[http.CanonicalHeaderKey(name)] {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// redactBody returns the body with sensitive values replaced. Channel params
+// hold webhook URLs and integration keys, so they are redacted as a whole.
+// Bodies that are not JSON are not logged.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	out, err := json.Marshal(redactValue(body, ""))
+	if err != nil {
+		return "<body omitted>"
+	}
+	return string(out)
+}
+
+func redactValue(value interface{}, parent string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			switch {
+			case sensitiveKeys[strings.ToLower(key)]:
+				v[key] = redacted
+			case key == "params" && parent == "channels":
+				v[key] = redactParams(child)
+			default:
+				v[key] = redactValue(child, key)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child, parent)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func redactParams(value interface{}) interface{} {
+	params, ok := value.(map[string]interface{})
+	if !ok {
+		return redacted
+	}
+	for key := range params {
+		params[key] = redacted
+	}
+	return params
+}
+
+// errReader returns err from every read.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
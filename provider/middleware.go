@@ -0,0 +1,28 @@
+package provider
+
+import "net/http"
+
+// Middleware wraps the RoundTripper that sends API requests, e.g. to sign
+// requests, record them for an audit log or replay recorded responses in
+// tests.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Option customizes a provider returned by New.
+type Option func(*HexagateProvider)
+
+// WithMiddleware adds middlewares to the transport of every API client the
+// provider configures. The first middleware is the outermost one and sees
+// each request first.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(p *HexagateProvider) {
+		p.middlewares = append(p.middlewares, middlewares...)
+	}
+}
+
+// wrapTransport applies the middlewares to the given transport.
+func wrapTransport(transport http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return transport
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -75,6 +77,7 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 							Description: "The type of the entity.",
 						},
 						"params": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
 							Computed:    true,
 							Description: "JSON encoded parameters for the entity.",
 						},
@@ -89,6 +92,10 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						"id": schema.Int64Attribute{
 							Computed: true,
 						},
+						"key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key of the rule. Always null, since keys only exist in Terraform configuration.",
+						},
 						"name": schema.StringAttribute{
 							Computed:    true,
 							Description: "The name of the rule.",
@@ -105,10 +112,36 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 							Computed:    true,
 							Description: "The notification period for the rule.",
 						},
+						"cooldown_seconds": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The cooldown of the rule, in seconds.",
+						},
+						"dedup_window_seconds": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The deduplication window of the rule, in seconds.",
+						},
+						"quiet_hours": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The quiet hours of the rule.",
+							Attributes: map[string]schema.Attribute{
+								"timezone": schema.StringAttribute{
+									Computed: true,
+								},
+								"start": schema.StringAttribute{
+									Computed: true,
+								},
+								"end": schema.StringAttribute{
+									Computed: true,
+								},
+								"severity_floor": schema.Int64Attribute{
+									Computed: true,
+								},
+							},
+						},
 						"categories": schema.ListAttribute{
 							Computed:    true,
-							Description: "The categories for the rule.",
-							ElementType: types.Int64Type,
+							Description: "The category IDs for the rule.",
+							ElementType: types.StringType,
 						},
 						"channels": schema.ListNestedAttribute{
 							Computed:    true,
@@ -123,6 +156,7 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 										Description: "The name of the channel.",
 									},
 									"params": schema.StringAttribute{
+										CustomType:  jsontypes.NormalizedType{},
 										Computed:    true,
 										Description: "JSON encoded parameters for the channel.",
 									},
@@ -133,6 +167,7 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				},
 			},
 			"params": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
 				Computed:    true,
 				Description: "JSON encoded parameters for the monitor.",
 			},
@@ -141,12 +176,14 @@ func (d *MonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Description: "The creator of the monitor.",
 			},
 			"created_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
 				Computed:    true,
-				Description: "The creation timestamp.",
+				Description: "The creation timestamp, in RFC 3339 format.",
 			},
 			"updated_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
 				Computed:    true,
-				Description: "The last update timestamp.",
+				Description: "The last update timestamp, in RFC 3339 format.",
 			},
 		},
 	}
@@ -162,11 +199,18 @@ func (d *MonitorDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	// Reuse the read function from the resource
 	resource := MonitorResource{client: d.client}
-	diags = resource.read(ctx, &state)
+	found, diags := resource.read(ctx, &state, d.client.BulkRead)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor",
+			fmt.Sprintf("Monitor ID %s does not exist.", state.ID.ValueString()),
+		)
+		return
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
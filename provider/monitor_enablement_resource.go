@@ -0,0 +1,471 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                     = &MonitorEnablementResource{}
+	_ resource.ResourceWithConfigure        = &MonitorEnablementResource{}
+	_ resource.ResourceWithConfigValidators = &MonitorEnablementResource{}
+)
+
+// previousDisabledKey is the private state key of the disabled values the
+// selected monitors had before the resource changed them.
+const previousDisabledKey = "previous_disabled"
+
+func NewMonitorEnablementResource() resource.Resource {
+	return &MonitorEnablementResource{}
+}
+
+// MonitorEnablementResource enables or disables a set of monitors without
+// managing their definitions, which stay with the configuration that owns
+// them.
+type MonitorEnablementResource struct {
+	client *Client
+}
+
+// MonitorEnablementResourceModel describes the resource data model.
+type MonitorEnablementResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	MonitorIDs         types.Set    `tfsdk:"monitor_ids"`
+	Tags               types.Set    `tfsdk:"tags"`
+	Disabled           types.Bool   `tfsdk:"disabled"`
+	RestoreOnDestroy   types.Bool   `tfsdk:"restore_on_destroy"`
+	SelectedMonitorIDs types.Set    `tfsdk:"selected_monitor_ids"`
+}
+
+func (r *MonitorEnablementResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *MonitorEnablementResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_enablement"
+}
+
+func (r *MonitorEnablementResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables or disables a set of monitors, selected by ID or by tags, without managing their definitions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitor_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The IDs of the monitors to enable or disable.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Selects the monitors that have all of these tags. Monitors that are tagged later are selected on the next apply.",
+			},
+			"disabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the selected monitors are disabled.",
+			},
+			"restore_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether monitors are set back to their previous disabled value when they are no longer selected or the resource is destroyed. Defaults to true.",
+			},
+			"selected_monitor_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The IDs of the monitors that are selected.",
+			},
+		},
+	}
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators.
+func (r *MonitorEnablementResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("monitor_ids"),
+			path.MatchRoot("tags"),
+		),
+	}
+}
+
+func (r *MonitorEnablementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var plan MonitorEnablementResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Monitors that were changed before an error are recorded as well, so
+	// that they are restored when the tainted resource is replaced.
+	previous := map[string]bool{}
+	r.apply(ctx, &plan, nil, previous, &resp.Diagnostics)
+	if plan.SelectedMonitorIDs.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(savePreviousDisabled(ctx, resp.Private, previous)...)
+
+	plan.ID = types.StringValue(enablementID(plan))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the selection. When a selected monitor's disabled value
+// differs from the configured one, disabled is set to the monitors' value, or
+// to null if they differ among themselves, so that the next plan reapplies it.
+func (r *MonitorEnablementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state MonitorEnablementResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitors, selected, diags := r.selectMonitors(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var values []bool
+	for _, id := range selected {
+		if !slices.Contains(values, monitors[id].Disabled) {
+			values = append(values, monitors[id].Disabled)
+		}
+	}
+	switch len(values) {
+	case 1:
+		state.Disabled = types.BoolValue(values[0])
+	case 2:
+		state.Disabled = types.BoolNull()
+	}
+
+	state.SelectedMonitorIDs, diags = monitorIDSet(ctx, selected)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *MonitorEnablementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var plan, state MonitorEnablementResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, diags := loadPreviousDisabled(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prior []string
+	if !state.SelectedMonitorIDs.IsNull() {
+		resp.Diagnostics.Append(state.SelectedMonitorIDs.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.apply(ctx, &plan, prior, previous, &resp.Diagnostics)
+	if plan.SelectedMonitorIDs.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(savePreviousDisabled(ctx, resp.Private, previous)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete sets the selected monitors back to their previous disabled value,
+// unless restore_on_destroy is false.
+func (r *MonitorEnablementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state MonitorEnablementResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !state.RestoreOnDestroy.ValueBool() {
+		return
+	}
+
+	previous, diags := loadPreviousDisabled(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitors, err := r.listMonitors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Restoring Monitors",
+			fmt.Sprintf("Could not list monitors: %s", err),
+		)
+		return
+	}
+	for key, disabled := range previous {
+		id, _ := strconv.Atoi(key)
+		if monitor, ok := monitors[id]; ok {
+			setDisabled(ctx, r.client.HexagateClient, monitor, monitor.Disabled, disabled, &resp.Diagnostics)
+		}
+	}
+}
+
+// apply sets the disabled value of the planned selection. Monitors in prior
+// that are no longer selected are restored when restore_on_destroy is set.
+// previous is updated with the values of newly selected monitors.
+func (r *MonitorEnablementResource) apply(ctx context.Context, plan *MonitorEnablementResourceModel, prior []string, previous map[string]bool, diags *diag.Diagnostics) {
+	monitors, selected, selectDiags := r.selectMonitors(ctx, *plan)
+	diags.Append(selectDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	// The disabled values set so far. The listed monitors may be shared
+	// through the MonitorListCache, so they keep the values they were listed
+	// with.
+	applied := map[int]bool{}
+	current := func(monitor *Monitor) bool {
+		if disabled, ok := applied[monitor.ID]; ok {
+			return disabled
+		}
+		return monitor.Disabled
+	}
+
+	for _, key := range prior {
+		id, _ := strconv.Atoi(key)
+		if slices.Contains(selected, id) {
+			continue
+		}
+		disabled, ok := previous[key]
+		delete(previous, key)
+		if monitor, exists := monitors[id]; ok && exists && plan.RestoreOnDestroy.ValueBool() {
+			if setDisabled(ctx, r.client.HexagateClient, monitor, current(monitor), disabled, diags) {
+				applied[id] = disabled
+			}
+		}
+	}
+
+	disabled := plan.Disabled.ValueBool()
+	for _, id := range selected {
+		monitor := monitors[id]
+		key := strconv.Itoa(id)
+		if _, ok := previous[key]; !ok {
+			previous[key] = current(monitor)
+		}
+		if setDisabled(ctx, r.client.HexagateClient, monitor, current(monitor), disabled, diags) {
+			applied[id] = disabled
+		}
+	}
+
+	var setDiags diag.Diagnostics
+	plan.SelectedMonitorIDs, setDiags = monitorIDSet(ctx, selected)
+	diags.Append(setDiags...)
+}
+
+// selectMonitors returns all monitors by ID and the sorted IDs of the selected
+// ones. Selected monitors that don't exist are left out with a warning.
+func (r *MonitorEnablementResource) selectMonitors(ctx context.Context, model MonitorEnablementResourceModel) (map[int]*Monitor, []int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	monitors, err := r.listMonitors(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Selecting Monitors",
+			fmt.Sprintf("Could not list monitors: %s", err),
+		)
+		return nil, nil, diags
+	}
+
+	var selected []int
+	if !model.MonitorIDs.IsNull() {
+		var keys []string
+		diags.Append(model.MonitorIDs.ElementsAs(ctx, &keys, false)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+		for _, key := range keys {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("monitor_ids"),
+					"Invalid Monitor ID",
+					fmt.Sprintf("Could not parse monitor ID %q: %s", key, err),
+				)
+				continue
+			}
+			if _, ok := monitors[id]; !ok {
+				diags.AddAttributeWarning(
+					path.Root("monitor_ids"),
+					"Monitor Not Found",
+					fmt.Sprintf("Monitor ID %d does not exist and is skipped.", id),
+				)
+				continue
+			}
+			selected = append(selected, id)
+		}
+	} else {
+		var tags []string
+		diags.Append(model.Tags.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+		for id, monitor := range monitors {
+			if hasAllTags(monitor.MonitorTags, tags) {
+				selected = append(selected, id)
+			}
+		}
+	}
+
+	sort.Ints(selected)
+	return monitors, selected, diags
+}
+
+func (r *MonitorEnablementResource) listMonitors(ctx context.Context) (map[int]*Monitor, error) {
+	list, err := r.client.HexagateClient.GetAllMonitors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	monitors := make(map[int]*Monitor, len(list))
+	for _, monitor := range list {
+		monitors[monitor.ID] = monitor
+	}
+	return monitors, nil
+}
+
+// setDisabled sets the disabled value of a monitor, which currently is
+// current, unless it already has it. It reports whether the monitor has the
+// value afterwards, and doesn't modify monitor. Like disabling on destroy, it
+// falls back to replacing the monitor when the API doesn't support partial
+// updates.
+func setDisabled(ctx context.Context, client MonitorAPI, monitor *Monitor, current, disabled bool, diags *diag.Diagnostics) bool {
+	if current == disabled {
+		return true
+	}
+
+	err := client.PatchMonitor(ctx, monitor.ID, map[string]interface{}{"disabled": disabled})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+		replacement := *monitor
+		replacement.Disabled = disabled
+		err = client.UpdateMonitor(ctx, monitor.ID, &replacement)
+	}
+	if isNotFound(err) {
+		return false
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Updating Monitor",
+			fmt.Sprintf("Could not set disabled to %t on monitor ID %d: %s", disabled, monitor.ID, err),
+		)
+		return false
+	}
+	return true
+}
+
+// hasAllTags reports whether a monitor's tags include all of tags.
+func hasAllTags(monitorTags, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(monitorTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func monitorIDSet(ctx context.Context, ids []int) (types.Set, diag.Diagnostics) {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// enablementID derives the ID of the resource from its selector.
+func enablementID(model MonitorEnablementResourceModel) string {
+	selector := model.MonitorIDs
+	prefix := "ids"
+	if selector.IsNull() {
+		selector = model.Tags
+		prefix = "tags"
+	}
+
+	var values []string
+	for _, element := range selector.Elements() {
+		values = append(values, element.(types.String).ValueString())
+	}
+	sort.Strings(values)
+	return prefix + ":" + contentHash(strings.Join(values, "\n"))[:16]
+}
+
+// loadPreviousDisabled reads the previous disabled values of the selected
+// monitors from private state, keyed by monitor ID.
+func loadPreviousDisabled(ctx context.Context, private privateState) (map[string]bool, diag.Diagnostics) {
+	previous := map[string]bool{}
+
+	data, diags := private.GetKey(ctx, previousDisabledKey)
+	if diags.HasError() || len(data) == 0 {
+		return previous, diags
+	}
+	if err := json.Unmarshal(data, &previous); err != nil {
+		diags.AddError(
+			"Error Reading Private State",
+			fmt.Sprintf("Could not decode the previous disabled values of the monitors from private state: %s", err),
+		)
+	}
+	return previous, diags
+}
+
+func savePreviousDisabled(ctx context.Context, private privateState, previous map[string]bool) diag.Diagnostics {
+	data, err := json.Marshal(previous)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error Writing Private State",
+			fmt.Sprintf("Could not encode the previous disabled values of the monitors: %s", err),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, previousDisabledKey, data)
+}
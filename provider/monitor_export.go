@@ -0,0 +1,456 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// exportHeader starts the configuration written by ExportMonitors.
+const exportHeader = `# Generated from the monitors of a Hexagate account. Review the configuration
+# before applying it: channel params may contain webhook URLs or other secrets
+# that are better moved into variables.
+
+`
+
+// hclIdentifierPattern matches the object keys that can be written without
+// quotes.
+var hclIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ExportMonitors writes the configuration of every monitor of the account as
+// a hexagate_monitor resource with an import block, so that monitors created
+// in the Hexagate UI can be brought under Terraform. The configuration is
+// rendered from the state that importing the monitor reads, so that it plans
+// without changes once imported. It returns the number of monitors written.
+func ExportMonitors(ctx context.Context, client MonitorAPI, w io.Writer) (int, error) {
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing monitors: %w", err)
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i].ID < monitors[j].ID })
+
+	r := &MonitorResource{client: &Client{HexagateClient: client}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	if _, err := io.WriteString(w, exportHeader); err != nil {
+		return 0, err
+	}
+
+	used := map[string]bool{}
+	count := 0
+	for _, monitor := range monitors {
+		id := strconv.Itoa(monitor.ID)
+		state, found, diags := r.importedState(ctx, schemaResp.Schema, id)
+		if diags.HasError() {
+			return count, fmt.Errorf("reading monitor ID %s: %s", id, diagnosticsSummary(diags))
+		}
+		// The monitor was deleted since it was listed
+		if !found {
+			continue
+		}
+
+		name := exportResourceName(monitor.Name, id, used)
+		var b strings.Builder
+		fmt.Fprintf(&b, "import {\n  to = hexagate_monitor.%s\n  id = %s\n}\n\n", name, hclString(id))
+		fmt.Fprintf(&b, "resource \"hexagate_monitor\" %s {\n", hclString(name))
+		if err := writeHCLBody(ctx, &b, schemaResp.Schema, tftypes.NewAttributePath(), state.Raw, 1); err != nil {
+			return count, fmt.Errorf("rendering monitor ID %s: %w", id, err)
+		}
+		b.WriteString("}\n\n")
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// importedState reads the state of a monitor the way importing it by its ID
+// and refreshing does. It reports whether the monitor exists.
+func (r *MonitorResource) importedState(ctx context.Context, s schema.Schema, id string) (tfsdk.State, bool, diag.Diagnostics) {
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+
+	diags := state.SetAttribute(ctx, path.Root("id"), id)
+	if diags.HasError() {
+		return state, false, diags
+	}
+
+	var model MonitorResourceModel
+	diags.Append(state.Get(ctx, &model)...)
+	if diags.HasError() {
+		return state, false, diags
+	}
+
+	found, readDiags := r.read(ctx, &model, false)
+	diags.Append(readDiags...)
+	if diags.HasError() || !found {
+		return state, found, diags
+	}
+
+	diags.Append(state.Set(ctx, &model)...)
+	return state, true, diags
+}
+
+// diagnosticsSummary joins the summaries and details of the errors of diags.
+func diagnosticsSummary(diags diag.Diagnostics) string {
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// exportResourceName derives a resource name from the name of a monitor.
+// Names already used get the monitor's ID appended.
+func exportResourceName(monitorName, id string, used map[string]bool) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(monitorName) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = strings.TrimSuffix("monitor_"+name, "_")
+	}
+	if used[name] {
+		name += "_" + id
+	}
+	used[name] = true
+	return name
+}
+
+// writeHCLBody writes the attributes of an object value, followed by its
+// nested blocks. Null values and attributes that can't be configured are
+// left out.
+func writeHCLBody(ctx context.Context, b *strings.Builder, s schema.Schema, p *tftypes.AttributePath, value tftypes.Value, depth int) error {
+	var fields map[string]tftypes.Value
+	if err := value.As(&fields); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indent := strings.Repeat("  ", depth)
+	start := b.Len()
+	var blocks []string
+	for _, name := range names {
+		field := fields[name]
+		fieldPath := p.WithAttributeName(name)
+
+		attribute, err := s.AttributeAtTerraformPath(ctx, fieldPath)
+		if err != nil {
+			// Not an attribute, so a nested block
+			blocks = append(blocks, name)
+			continue
+		}
+		if field.IsNull() || (attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired()) {
+			continue
+		}
+		if value := schemaDefault(ctx, attribute); value != nil {
+			if defaultValue, err := value.ToTerraformValue(ctx); err == nil && defaultValue.Equal(field) {
+				continue
+			}
+		}
+
+		var expression string
+		if attribute.GetType().Equal(jsontypes.NormalizedType{}) {
+			expression, err = hclJSONExpression(field, depth)
+		} else {
+			expression, err = hclExpression(ctx, s, fieldPath, field, depth)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		fmt.Fprintf(b, "%s%s = %s\n", indent, name, expression)
+	}
+
+	for _, name := range blocks {
+		field := fields[name]
+		if field.IsNull() {
+			continue
+		}
+		fieldPath := p.WithAttributeName(name)
+
+		if field.Type().Is(tftypes.Object{}) {
+			if b.Len() > start {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s%s {\n", indent, name)
+			if err := writeHCLBody(ctx, b, s, fieldPath, field, depth+1); err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "%s}\n", indent)
+			continue
+		}
+
+		var elements []tftypes.Value
+		if err := field.As(&elements); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for i, element := range elements {
+			elementPath := fieldPath.WithElementKeyValue(element)
+			if field.Type().Is(tftypes.List{}) {
+				elementPath = fieldPath.WithElementKeyInt(i)
+			}
+			if b.Len() > start {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s%s {\n", indent, name)
+			if err := writeHCLBody(ctx, b, s, elementPath, element, depth+1); err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "%s}\n", indent)
+		}
+	}
+	return nil
+}
+
+// schemaDefault returns the default value of an attribute, or nil when it has
+// none.
+func schemaDefault(ctx context.Context, attribute interface{}) attr.Value {
+	switch attribute := attribute.(type) {
+	case interface{ BoolDefaultValue() defaults.Bool }:
+		if d := attribute.BoolDefaultValue(); d != nil {
+			var resp defaults.BoolResponse
+			d.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			return resp.PlanValue
+		}
+	case interface{ StringDefaultValue() defaults.String }:
+		if d := attribute.StringDefaultValue(); d != nil {
+			var resp defaults.StringResponse
+			d.DefaultString(ctx, defaults.StringRequest{}, &resp)
+			return resp.PlanValue
+		}
+	case interface{ Int64DefaultValue() defaults.Int64 }:
+		if d := attribute.Int64DefaultValue(); d != nil {
+			var resp defaults.Int64Response
+			d.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+			return resp.PlanValue
+		}
+	case interface{ ListDefaultValue() defaults.List }:
+		if d := attribute.ListDefaultValue(); d != nil {
+			var resp defaults.ListResponse
+			d.DefaultList(ctx, defaults.ListRequest{}, &resp)
+			return resp.PlanValue
+		}
+	case interface{ SetDefaultValue() defaults.Set }:
+		if d := attribute.SetDefaultValue(); d != nil {
+			var resp defaults.SetResponse
+			d.DefaultSet(ctx, defaults.SetRequest{}, &resp)
+			return resp.PlanValue
+		}
+	}
+	return nil
+}
+
+// hclExpression renders the value of an attribute. Objects are the values of
+// nested attributes, whose attributes are looked up in the schema.
+func hclExpression(ctx context.Context, s schema.Schema, p *tftypes.AttributePath, value tftypes.Value, depth int) (string, error) {
+	if value.IsNull() {
+		return "null", nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var str string
+		err := value.As(&str)
+		return hclString(str), err
+	case value.Type().Is(tftypes.Bool), value.Type().Is(tftypes.Number):
+		item, err := terraformValueToJSON(value)
+		return fmt.Sprint(item), err
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+		items := make([]string, len(elements))
+		for i, element := range elements {
+			elementPath := p.WithElementKeyValue(element)
+			if value.Type().Is(tftypes.List{}) {
+				elementPath = p.WithElementKeyInt(i)
+			}
+			item, err := hclExpression(ctx, s, elementPath, element, depth+1)
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return hclList(items, depth), nil
+	case value.Type().Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+		items := map[string]string{}
+		for key, element := range elements {
+			item, err := hclExpression(ctx, s, p.WithElementKeyString(key), element, depth+1)
+			if err != nil {
+				return "", err
+			}
+			items[key] = item
+		}
+		return hclObject(items, depth), nil
+	case value.Type().Is(tftypes.Object{}):
+		var b strings.Builder
+		if err := writeHCLBody(ctx, &b, s, p, value, depth+1); err != nil {
+			return "", err
+		}
+		if b.Len() == 0 {
+			return "{}", nil
+		}
+		return "{\n" + b.String() + strings.Repeat("  ", depth) + "}", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// hclJSONExpression renders the value of a JSON string attribute as a call of
+// jsonencode, which is easier to read and edit than the JSON string.
+func hclJSONExpression(value tftypes.Value, depth int) (string, error) {
+	var document string
+	if err := value.As(&document); err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", err
+	}
+	return "jsonencode(" + hclJSONValue(decoded, depth) + ")", nil
+}
+
+// hclJSONValue renders a decoded JSON value.
+func hclJSONValue(value interface{}, depth int) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return hclString(value)
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = hclJSONValue(item, depth+1)
+		}
+		return hclList(items, depth)
+	case map[string]interface{}:
+		items := make(map[string]string, len(value))
+		for key, item := range value {
+			items[key] = hclJSONValue(item, depth+1)
+		}
+		return hclObject(items, depth)
+	default:
+		// Numbers and booleans
+		return fmt.Sprint(value)
+	}
+}
+
+// hclList renders a list of rendered items, on one line unless an item spans
+// several.
+func hclList(items []string, depth int) string {
+	if len(items) == 0 {
+		return "[]"
+	}
+
+	multiline := false
+	for _, item := range items {
+		multiline = multiline || strings.Contains(item, "\n")
+	}
+	if !multiline {
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	indent := strings.Repeat("  ", depth+1)
+	return "[\n" + indent + strings.Join(items, ",\n"+indent) + ",\n" + strings.Repeat("  ", depth) + "]"
+}
+
+// hclObject renders an object of rendered items, with its keys in order.
+func hclObject(items map[string]string, depth int) string {
+	if len(items) == 0 {
+		return "{}"
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, key := range keys {
+		name := key
+		if !hclIdentifierPattern.MatchString(key) || key == "null" || key == "true" || key == "false" {
+			name = hclString(key)
+		}
+		fmt.Fprintf(&b, "%s%s = %s\n", indent, name, items[key])
+	}
+	b.WriteString(strings.Repeat("  ", depth) + "}")
+	return b.String()
+}
+
+// hclString renders a quoted string, escaping the sequences that would start
+// a template interpolation or directive.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r) && r <= 0xFFFF:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\U%08X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &MonitorHealthDataSource{}
+
+func NewMonitorHealthDataSource() datasource.DataSource {
+	return &MonitorHealthDataSource{}
+}
+
+// MonitorHealthDataSource reports whether a monitor exists, is enabled and
+// notifies at least one channel, for assertions in check blocks. Unlike the
+// other data sources, a missing monitor is not an error.
+type MonitorHealthDataSource struct {
+	client *Client
+}
+
+// MonitorHealthDataSourceModel describes the data source data model.
+type MonitorHealthDataSourceModel struct {
+	MonitorID    types.String `tfsdk:"monitor_id"`
+	Exists       types.Bool   `tfsdk:"exists"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	ChannelCount types.Int64  `tfsdk:"channel_count"`
+	Healthy      types.Bool   `tfsdk:"healthy"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+func (d *MonitorHealthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MonitorHealthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_health"
+}
+
+func (d *MonitorHealthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports whether a monitor exists, is enabled and notifies at least one channel, for assertions in check blocks.",
+		Attributes: map[string]schema.Attribute{
+			"monitor_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the monitor.",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the monitor exists.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the monitor exists and is enabled.",
+			},
+			"channel_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of distinct channels the monitor's rules notify.",
+			},
+			"healthy": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the monitor exists, is enabled and notifies at least one channel.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *MonitorHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state MonitorHealthDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.Atoi(state.MonitorID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Health",
+			fmt.Sprintf("Could not parse monitor ID: %s", err),
+		)
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitor, err := client.GetMonitor(ctx, id)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Health",
+			fmt.Sprintf("Could not read monitor ID %d: %s", id, err),
+		)
+		return
+	}
+
+	var channels int
+	if monitor != nil {
+		channels = countChannels(monitor)
+	}
+	state.Exists = types.BoolValue(monitor != nil)
+	state.Enabled = types.BoolValue(monitor != nil && !monitor.Disabled)
+	state.ChannelCount = types.Int64Value(int64(channels))
+	state.Healthy = types.BoolValue(monitor != nil && !monitor.Disabled && channels > 0)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// countChannels returns the number of distinct channels the rules of a monitor
+// notify. Channels shared by several rules are counted once.
+func countChannels(monitor *Monitor) int {
+	seen := map[int]bool{}
+	count := 0
+	for _, rule := range monitor.MonitorRules {
+		for _, channel := range rule.Channels {
+			if channel.ID != 0 {
+				if seen[channel.ID] {
+					continue
+				}
+				seen[channel.ID] = true
+			}
+			count++
+		}
+	}
+	return count
+}
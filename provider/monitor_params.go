@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// typedParamsAttributes are the structured alternatives to the params JSON
+// attribute. At most one of them, or params, may be configured; the one that
+// is set selects the shape of the monitor's params.
+var typedParamsAttributes = []string{"threshold_params", "address_params", "token_params"}
+
+// ThresholdParamsModel describes params of monitors that alert when a value
+// crosses a threshold.
+type ThresholdParamsModel struct {
+	Threshold types.Float64 `tfsdk:"threshold"`
+	Window    types.String  `tfsdk:"window"`
+}
+
+// AddressParamsModel describes params of monitors that watch a list of addresses.
+type AddressParamsModel struct {
+	Addresses types.List `tfsdk:"addresses"`
+}
+
+// TokenParamsModel describes params of monitors that watch a list of tokens.
+type TokenParamsModel struct {
+	Tokens    types.List    `tfsdk:"tokens"`
+	MinAmount types.Float64 `tfsdk:"min_amount"`
+}
+
+func typedParamsSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"threshold_params": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Params for monitors that alert when a value crosses a threshold. Conflicts with params and the other typed params.",
+			Attributes: map[string]schema.Attribute{
+				"threshold": schema.Float64Attribute{
+					Required:    true,
+					Description: "The value that triggers an alert when crossed.",
+				},
+				"window": schema.StringAttribute{
+					Optional:    true,
+					Description: "The time window the value is aggregated over, e.g. \"1h\".",
+				},
+			},
+		},
+		"address_params": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Params for monitors that watch a list of addresses. Conflicts with params and the other typed params.",
+			Attributes: map[string]schema.Attribute{
+				"addresses": schema.ListAttribute{
+					Required:    true,
+					ElementType: types.StringType,
+					Description: "The addresses to watch.",
+				},
+			},
+		},
+		"token_params": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Params for monitors that watch a list of tokens. Conflicts with params and the other typed params.",
+			Attributes: map[string]schema.Attribute{
+				"tokens": schema.ListAttribute{
+					Required:    true,
+					ElementType: types.StringType,
+					Description: "The addresses of the tokens to watch.",
+				},
+				"min_amount": schema.Float64Attribute{
+					Optional:    true,
+					Description: "The minimum amount of a token transfer to alert on.",
+				},
+			},
+		},
+	}
+}
+
+// typedParams returns the monitor params described by the configured typed
+// params, or nil if none are configured.
+func typedParams(ctx context.Context, model MonitorResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case model.ThresholdParams != nil:
+		params := map[string]interface{}{
+			"threshold": model.ThresholdParams.Threshold.ValueFloat64(),
+		}
+		if !model.ThresholdParams.Window.IsNull() {
+			params["window"] = model.ThresholdParams.Window.ValueString()
+		}
+		return params, diags
+
+	case model.AddressParams != nil:
+		var addresses []string
+		diags.Append(model.AddressParams.Addresses.ElementsAs(ctx, &addresses, false)...)
+		return map[string]interface{}{"addresses": addresses}, diags
+
+	case model.TokenParams != nil:
+		var tokens []string
+		diags.Append(model.TokenParams.Tokens.ElementsAs(ctx, &tokens, false)...)
+		params := map[string]interface{}{"tokens": tokens}
+		if !model.TokenParams.MinAmount.IsNull() {
+			params["min_amount"] = model.TokenParams.MinAmount.ValueFloat64()
+		}
+		return params, diags
+	}
+
+	return nil, diags
+}
+
+// refreshTypedParams updates the configured typed params from the params
+// returned by the API, so that changes made outside Terraform show up as drift.
+func refreshTypedParams(model *MonitorResourceModel, params map[string]interface{}) {
+	switch {
+	case model.ThresholdParams != nil:
+		model.ThresholdParams.Threshold = float64Param(params, "threshold")
+		model.ThresholdParams.Window = stringParam(params, "window")
+
+	case model.AddressParams != nil:
+		model.AddressParams.Addresses = stringListParam(params, "addresses")
+
+	case model.TokenParams != nil:
+		model.TokenParams.Tokens = stringListParam(params, "tokens")
+		model.TokenParams.MinAmount = float64Param(params, "min_amount")
+	}
+}
+
+func float64Param(params map[string]interface{}, key string) types.Float64 {
+	if value, ok := params[key].(float64); ok {
+		return types.Float64Value(value)
+	}
+	return types.Float64Null()
+}
+
+func stringParam(params map[string]interface{}, key string) types.String {
+	if value, ok := params[key].(string); ok {
+		return types.StringValue(value)
+	}
+	return types.StringNull()
+}
+
+func stringListParam(params map[string]interface{}, key string) types.List {
+	items, ok := params[key].([]interface{})
+	if !ok {
+		return types.ListNull(types.StringType)
+	}
+
+	values := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, types.StringValue(value))
+		}
+	}
+	return types.ListValueMust(types.StringType, values)
+}
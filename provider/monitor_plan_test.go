@@ -0,0 +1,179 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// planTestMonitor returns the configuration of a monitor with the given
+// rules, and further attributes, as JSON object members.
+func planTestMonitor(rules string, attributes ...string) string {
+	return fmt.Sprintf(`{
+		"name": "tf-acc-test-plan",
+		"description": "Plan test",
+		"monitor_id": 1,
+		"entities": [{"entity_type": 1, "address": "0x1f98431c8ad98523631ae4a59f267346ea31f984", "chain_id": 1}],
+		"monitor_rules": [%s]%s
+	}`, rules, strings.Join(append([]string{""}, attributes...), ",\n"))
+}
+
+// planTestRule returns the configuration of a rule notifying a channel named
+// after it.
+func planTestRule(name string, threshold int, attributes ...string) string {
+	return fmt.Sprintf(`{
+		"name": %q, "type": "notification", "threshold": %d, "categories": ["1"],
+		"channels": [{"name": "%s-channel", "params": "{\"url\":\"https://hooks.example.com/%s\"}"}]%s
+	}`, name, threshold, name, name, strings.Join(append([]string{""}, attributes...), ", "))
+}
+
+// TestMonitorPlanRuleIDs checks that updates plan the IDs of the rules that
+// are kept, matched by their name or key, so that applying them keeps the
+// rules and is consistent with the plan.
+func TestMonitorPlanRuleIDs(t *testing.T) {
+	tests := map[string]struct {
+		before, after string
+		// kept maps the index of each rule after the update to the index
+		// of the rule it keeps the ID of.
+		kept map[int]int
+	}{
+		"changed threshold": {
+			before: planTestMonitor(planTestRule("a", 50) + "," + planTestRule("b", 50)),
+			after:  planTestMonitor(planTestRule("a", 90) + "," + planTestRule("b", 50)),
+			kept:   map[int]int{0: 0, 1: 1},
+		},
+		"reordered": {
+			before: planTestMonitor(planTestRule("a", 50) + "," + planTestRule("b", 50)),
+			after:  planTestMonitor(planTestRule("b", 50) + "," + planTestRule("a", 50)),
+			kept:   map[int]int{0: 1, 1: 0},
+		},
+		"renamed with key": {
+			before: planTestMonitor(planTestRule("a", 50, `"key": "first"`)),
+			after:  planTestMonitor(planTestRule("renamed", 50, `"key": "first"`)),
+			kept:   map[int]int{0: 0},
+		},
+		"added": {
+			before: planTestMonitor(planTestRule("a", 50)),
+			after:  planTestMonitor(planTestRule("new", 50) + "," + planTestRule("a", 50)),
+			kept:   map[int]int{1: 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := newFakeTerraform(t, provider.NewFakeMonitorAPI(), "hexagate_monitor")
+			tf.apply(test.before)
+			prior := tf.state
+
+			plan, err := tf.plan(test.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for after, before := range test.kept {
+				want := valueAt(t, prior, "monitor_rules", before, "id")
+				if got := valueAt(t, plan.planned, "monitor_rules", after, "id"); !got.Equal(want) {
+					t.Errorf("planned ID of rule %d = %s, want %s", after, got, want)
+				}
+			}
+
+			tf.apply(test.after)
+			for after, before := range test.kept {
+				want := valueAt(t, prior, "monitor_rules", before, "id")
+				if got := tf.attribute("monitor_rules", after, "id"); !got.Equal(want) {
+					t.Errorf("ID of rule %d = %s, want %s", after, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestMonitorPlanUnconfiguredParams checks that params the configuration
+// leaves to the API are planned with their prior value on update, instead of
+// becoming unknown.
+func TestMonitorPlanUnconfiguredParams(t *testing.T) {
+	ctx := context.Background()
+	api := provider.NewFakeMonitorAPI()
+	tf := newFakeTerraform(t, api, "hexagate_monitor")
+	tf.apply(planTestMonitor(planTestRule("a", 50)))
+
+	id, _ := strconv.Atoi(stringValue(t, tf.attribute("id")))
+	if err := api.PatchMonitor(ctx, id, map[string]interface{}{"params": map[string]interface{}{"window": "1h"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := planTestMonitor(planTestRule("a", 50), `"description": "updated"`)
+	plan, err := tf.plan(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := valueAt(t, plan.planned, "params"); !got.IsKnown() || stringValue(t, got) != `{"window":"1h"}` {
+		t.Errorf("planned params = %s, want the params set in the API", got)
+	}
+
+	tf.apply(config)
+	monitor, err := api.GetMonitor(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if monitor.Params["window"] != "1h" {
+		t.Errorf("params after the update = %v, want them kept", monitor.Params)
+	}
+}
+
+// TestMonitorPlanParamsSubset checks that params the API returns in addition
+// to the configured ones don't show as a change, and that updates keep the
+// prior params planned, so that the result is consistent with the plan.
+func TestMonitorPlanParamsSubset(t *testing.T) {
+	ctx := context.Background()
+	api := provider.NewFakeMonitorAPI()
+	tf := newFakeTerraform(t, api, "hexagate_monitor")
+	params := `"params": "{\"threshold\":1}"`
+	tf.apply(planTestMonitor(planTestRule("a", 50), params))
+
+	// The platform adds a default
+	id, _ := strconv.Atoi(stringValue(t, tf.attribute("id")))
+	if err := api.PatchMonitor(ctx, id, map[string]interface{}{"params": map[string]interface{}{"threshold": 1, "window": "1h"}}); err != nil {
+		t.Fatal(err)
+	}
+	tf.assertNoChanges(planTestMonitor(planTestRule("a", 50), params))
+
+	tf.apply(planTestMonitor(planTestRule("a", 90), params))
+	if got := stringValue(t, tf.attribute("params")); got != `{"threshold":1,"window":"1h"}` {
+		t.Errorf("params = %s, want the params returned by the API", got)
+	}
+}
+
+// TestMonitorPlanChannelByID checks that channels given by their ID only plan
+// the name and params of the channel, so that they don't show as a change.
+func TestMonitorPlanChannelByID(t *testing.T) {
+	api := provider.NewFakeMonitorAPI()
+	owner := newFakeTerraform(t, api, "hexagate_monitor")
+	owner.apply(planTestMonitor(planTestRule("a", 50)))
+
+	var channelIDs map[string]tftypes.Value
+	if err := owner.attribute("channel_ids").As(&channelIDs); err != nil {
+		t.Fatal(err)
+	}
+	channelID := int64Value(t, channelIDs["a/a-channel"])
+
+	rule := func(threshold int) string {
+		return fmt.Sprintf(`{"name": "shared", "type": "notification", "threshold": %d, "categories": ["1"], "channels": [{"id": %d}]}`, threshold, channelID)
+	}
+	tf := newFakeTerraform(t, api, "hexagate_monitor")
+	tf.apply(planTestMonitor(rule(50)))
+
+	var channels []tftypes.Value
+	if err := tf.attribute("monitor_rules", 0, "channels").As(&channels); err != nil || len(channels) != 1 {
+		t.Fatalf("channels = %v, %v, want the referenced channel", channels, err)
+	}
+	if got := stringValue(t, valueAt(t, channels[0], "name")); got != "a-channel" {
+		t.Errorf("channel name = %q, want the name of the referenced channel", got)
+	}
+
+	tf.apply(planTestMonitor(rule(90)))
+}
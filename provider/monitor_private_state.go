@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// serverIDsKey is the private state key of the IDs the API assigned to the
+// rules and channels of a monitor.
+const serverIDsKey = "server_ids"
+
+// privateState is the private state of a resource, as passed to and returned
+// from the resource's operations.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// serverIDs maps the content hashes of rules and channels to the IDs the API
+// assigned to them. Unlike names and positions, the content of a rule or
+// channel usually survives refactoring the configuration, so planned rules and
+// channels that can't be matched by name are matched by their content instead
+// of being replaced.
+type serverIDs struct {
+	Rules    map[string]int64 `json:"rules,omitempty"`
+	Channels map[string]int64 `json:"channels,omitempty"`
+}
+
+// serverIDsFromModel collects the IDs of the rules and channels of a monitor.
+// Content hashes shared by rules or channels with different IDs are left out,
+// since they don't identify either.
+func serverIDsFromModel(ctx context.Context, model MonitorResourceModel) (serverIDs, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := serverIDs{Rules: map[string]int64{}, Channels: map[string]int64{}}
+	if model.MonitorRules.IsNull() || model.MonitorRules.IsUnknown() {
+		return ids, diags
+	}
+
+	var rules []MonitorRuleModel
+	diags.Append(model.MonitorRules.ElementsAs(ctx, &rules, false)...)
+	if diags.HasError() {
+		return ids, diags
+	}
+
+	// Channels defined by their name and params have their ID in
+	// channel_ids only.
+	channelIDs := map[string]int64{}
+	if !model.ChannelIDs.IsNull() && !model.ChannelIDs.IsUnknown() {
+		diags.Append(model.ChannelIDs.ElementsAs(ctx, &channelIDs, false)...)
+		if diags.HasError() {
+			return ids, diags
+		}
+	}
+
+	ambiguousRules, ambiguousChannels := map[string]bool{}, map[string]bool{}
+	add := func(ids map[string]int64, ambiguous map[string]bool, hash string, id int64) {
+		if existing, ok := ids[hash]; ok && existing != id {
+			ambiguous[hash] = true
+		}
+		ids[hash] = id
+	}
+
+	for _, rule := range rules {
+		if hash, ok := ruleHash(rule); ok && !rule.ID.IsNull() && !rule.ID.IsUnknown() {
+			add(ids.Rules, ambiguousRules, hash, rule.ID.ValueInt64())
+		}
+
+		if rule.Channels.IsNull() || rule.Channels.IsUnknown() {
+			continue
+		}
+		var channels []ChannelModel
+		diags.Append(rule.Channels.ElementsAs(ctx, &channels, false)...)
+		if diags.HasError() {
+			return ids, diags
+		}
+		for _, channel := range channels {
+			hash, ok := channelHash(channel)
+			if !ok {
+				continue
+			}
+			if !channel.ID.IsNull() && !channel.ID.IsUnknown() {
+				add(ids.Channels, ambiguousChannels, hash, channel.ID.ValueInt64())
+			} else if id, ok := channelIDs[rule.Name.ValueString()+"/"+channel.Name.ValueString()]; ok {
+				add(ids.Channels, ambiguousChannels, hash, id)
+			}
+		}
+	}
+
+	for hash := range ambiguousRules {
+		delete(ids.Rules, hash)
+	}
+	for hash := range ambiguousChannels {
+		delete(ids.Channels, hash)
+	}
+	return ids, diags
+}
+
+// loadServerIDs reads the IDs stored in private state. Private state written
+// by earlier versions of the provider has none.
+func loadServerIDs(ctx context.Context, private privateState) (serverIDs, diag.Diagnostics) {
+	var ids serverIDs
+
+	data, diags := private.GetKey(ctx, serverIDsKey)
+	if diags.HasError() || len(data) == 0 {
+		return ids, diags
+	}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		diags.AddError(
+			"Error Reading Private State",
+			fmt.Sprintf("Could not decode the IDs of the monitor's rules and channels from private state: %s", err),
+		)
+	}
+	return ids, diags
+}
+
+// saveServerIDs stores the IDs of the rules and channels of a monitor in
+// private state.
+func saveServerIDs(ctx context.Context, private privateState, model MonitorResourceModel) diag.Diagnostics {
+	ids, diags := serverIDsFromModel(ctx, model)
+	if diags.HasError() {
+		return diags
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		diags.AddError(
+			"Error Writing Private State",
+			fmt.Sprintf("Could not encode the IDs of the monitor's rules and channels: %s", err),
+		)
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, serverIDsKey, data)...)
+	return diags
+}
+
+// ruleHash returns the content hash of a rule: its settings other than its
+// ID, key, name and channels. It reports false when any of them is unknown.
+func ruleHash(rule MonitorRuleModel) (string, bool) {
+	fields := []interface {
+		IsUnknown() bool
+		String() string
+	}{
+		rule.Type,
+		rule.Threshold,
+		rule.NotificationPeriod,
+		rule.CooldownSeconds,
+		rule.DedupWindowSeconds,
+		rule.QuietHours,
+		rule.Categories,
+	}
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		if field.IsUnknown() {
+			return "", false
+		}
+		parts[i] = field.String()
+	}
+	return contentHash(strings.Join(parts, "\n")), true
+}
+
+// channelHash returns the content hash of a channel: its params, regardless
+// of their formatting. It reports false when the params are null or unknown,
+// as for channels referring to an existing channel by ID.
+func channelHash(channel ChannelModel) (string, bool) {
+	if channel.Params.IsNull() || channel.Params.IsUnknown() {
+		return "", false
+	}
+
+	var params interface{}
+	if channel.Params.Unmarshal(&params).HasError() {
+		return "", false
+	}
+	return paramsHash(params)
+}
+
+// paramsHash returns the content hash of decoded JSON params.
+func paramsHash(params interface{}) (string, bool) {
+	// Objects are encoded with sorted keys.
+	normalized, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	return contentHash(string(normalized)), true
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
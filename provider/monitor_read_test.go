@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOptionalStringValue(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		prior types.String
+		want  types.String
+	}{
+		"empty with null prior": {
+			value: "",
+			prior: types.StringNull(),
+			want:  types.StringNull(),
+		},
+		"empty with unknown prior": {
+			value: "",
+			prior: types.StringUnknown(),
+			want:  types.StringNull(),
+		},
+		"empty with empty prior": {
+			value: "",
+			prior: types.StringValue(""),
+			want:  types.StringValue(""),
+		},
+		"empty with set prior": {
+			value: "",
+			prior: types.StringValue("old"),
+			want:  types.StringValue(""),
+		},
+		"set with null prior": {
+			value: "new",
+			prior: types.StringNull(),
+			want:  types.StringValue("new"),
+		},
+		"set with set prior": {
+			value: "new",
+			prior: types.StringValue("old"),
+			want:  types.StringValue("new"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := optionalStringValue(test.value, test.prior); !got.Equal(test.want) {
+				t.Errorf("optionalStringValue(%q, %s) = %s, want %s", test.value, test.prior, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParamsValue(t *testing.T) {
+	tests := map[string]struct {
+		params map[string]interface{}
+		want   string
+	}{
+		"nil":   {params: nil, want: `{}`},
+		"empty": {params: map[string]interface{}{}, want: `{}`},
+		"set":   {params: map[string]interface{}{"b": 1, "a": "x"}, want: `{"a":"x","b":1}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := paramsValue(test.params).ValueString(); got != test.want {
+				t.Errorf("paramsValue() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// TestReadDescription checks that reading a monitor without a description
+// keeps whichever of null and "" the prior state has, so that neither an
+// unset nor an empty description shows as a change.
+func TestReadDescription(t *testing.T) {
+	tests := map[string]struct {
+		description string
+		prior       types.String
+		want        types.String
+	}{
+		"unset in API, null in state": {
+			prior: types.StringNull(),
+			want:  types.StringNull(),
+		},
+		"unset in API, empty in state": {
+			prior: types.StringValue(""),
+			want:  types.StringValue(""),
+		},
+		"unset in API, set in state": {
+			prior: types.StringValue("old"),
+			want:  types.StringValue(""),
+		},
+		"set in API, null in state": {
+			description: "new",
+			prior:       types.StringNull(),
+			want:        types.StringValue("new"),
+		},
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&MonitorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := NewFakeMonitorAPI()
+			created, err := api.CreateMonitor(ctx, &Monitor{Name: "m", MonitorID: 1, Description: test.description})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &MonitorResource{client: &Client{HexagateClient: api}}
+
+			state, found, diags := r.importedState(ctx, schemaResp.Schema, strconv.Itoa(created.ID))
+			if diags.HasError() || !found {
+				t.Fatalf("importing the monitor: found %t, %v", found, diags)
+			}
+			var model MonitorResourceModel
+			if diags := state.Get(ctx, &model); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			model.Description = test.prior
+			if _, diags := r.read(ctx, &model, false); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !model.Description.Equal(test.want) {
+				t.Errorf("description = %s, want %s", model.Description, test.want)
+			}
+		})
+	}
+}
@@ -3,20 +3,41 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
-
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/smartcontracts/terraform-provider-hexagate/internal/jsonutil"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -25,6 +46,21 @@ var (
 	_ resource.ResourceWithConfigure   = &MonitorResource{}
 	_ resource.ResourceWithImportState = &MonitorResource{}
 	_ resource.ResourceWithModifyPlan  = &MonitorResource{}
+
+	_ resource.ResourceWithValidateConfig   = &MonitorResource{}
+	_ resource.ResourceWithConfigValidators = &MonitorResource{}
+)
+
+// monitorAttributes are the attributes API field errors can be attached to.
+var monitorAttributes = []string{"name", "monitor_id", "description", "disabled", "entities", "monitor_rules", "params"}
+
+// Default timeouts of monitor operations, used unless the timeouts block
+// overrides them. They bound the operation including all retries.
+const (
+	defaultMonitorCreateTimeout = 20 * time.Minute
+	defaultMonitorReadTimeout   = 5 * time.Minute
+	defaultMonitorUpdateTimeout = 20 * time.Minute
+	defaultMonitorDeleteTimeout = 20 * time.Minute
 )
 
 // NewMonitorResource is a helper function to simplify the provider implementation.
@@ -39,43 +75,181 @@ type MonitorResource struct {
 
 // MonitorResourceModel describes the resource data model.
 type MonitorResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	MonitorID    types.Int64  `tfsdk:"monitor_id"`
-	Description  types.String `tfsdk:"description"`
-	Disabled     types.Bool   `tfsdk:"disabled"`
-	Entities     types.List   `tfsdk:"entities"`
-	MonitorRules types.List   `tfsdk:"monitor_rules"`
-	Params       types.String `tfsdk:"params"`
-	CreatedBy    types.String `tfsdk:"created_by"`
-	CreatedAt    types.String `tfsdk:"created_at"`
-	UpdatedAt    types.String `tfsdk:"updated_at"`
+	ID                types.String         `tfsdk:"id"`
+	Name              types.String         `tfsdk:"name"`
+	MonitorID         types.Int64          `tfsdk:"monitor_id"`
+	Description       types.String         `tfsdk:"description"`
+	Disabled          types.Bool           `tfsdk:"disabled"`
+	Entities          types.Set            `tfsdk:"entities"`
+	EntitiesForChains types.Set            `tfsdk:"entities_for_chains"`
+	FactoryEntities   types.Set            `tfsdk:"factory_entities"`
+	MonitorRules      types.List           `tfsdk:"monitor_rules"`
+	Params            jsontypes.Normalized `tfsdk:"params"`
+	Tags              types.Set            `tfsdk:"tags"`
+	EntityTags        types.Set            `tfsdk:"entity_tags"`
+	Wallets           types.List           `tfsdk:"wallets"`
+
+	ThresholdParams *ThresholdParamsModel `tfsdk:"threshold_params"`
+	AddressParams   *AddressParamsModel   `tfsdk:"address_params"`
+	TokenParams     *TokenParamsModel     `tfsdk:"token_params"`
+
+	CreatedBy types.String      `tfsdk:"created_by"`
+	CreatedAt timetypes.RFC3339 `tfsdk:"created_at"`
+	UpdatedAt timetypes.RFC3339 `tfsdk:"updated_at"`
+
+	RuleIDs             types.Map `tfsdk:"rule_ids"`
+	ChannelIDs          types.Map `tfsdk:"channel_ids"`
+	DiscoveredAddresses types.Map `tfsdk:"discovered_addresses"`
+
+	ReadAlertStats  types.Bool        `tfsdk:"read_alert_stats"`
+	OpenAlertCount  types.Int64       `tfsdk:"open_alert_count"`
+	LastTriggeredAt timetypes.RFC3339 `tfsdk:"last_triggered_at"`
+
+	DisableOnDestroy   types.Bool `tfsdk:"disable_on_destroy"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool `tfsdk:"force_delete"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+
+	ParamsMergeStrategy    types.String `tfsdk:"params_merge_strategy"`
+	IgnoreParamsArrayOrder types.Bool   `tfsdk:"ignore_params_array_order"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+	Timeouts       timeouts.Value       `tfsdk:"timeouts"`
+
+	CloneFromID types.Int64 `tfsdk:"clone_from_id"`
 }
 
 // EntityModel describes an entity in the monitor.
 type EntityModel struct {
+	EntityType types.Int64          `tfsdk:"entity_type"`
+	Params     jsontypes.Normalized `tfsdk:"params"`
+	Address    types.String         `tfsdk:"address"`
+	ChainID    types.Int64          `tfsdk:"chain_id"`
+}
+
+// EntitiesForChainsModel describes an address that is monitored on several
+// chains, expanded into one entity per chain.
+type EntitiesForChainsModel struct {
 	EntityType types.Int64  `tfsdk:"entity_type"`
-	Params     types.String `tfsdk:"params"`
+	Address    types.String `tfsdk:"address"`
+	ChainIDs   types.Set    `tfsdk:"chain_ids"`
+}
+
+// FactoryEntityModel describes a factory or registry contract whose child
+// contracts Hexagate discovers and monitors.
+type FactoryEntityModel struct {
+	EntityType     types.Int64  `tfsdk:"entity_type"`
+	FactoryAddress types.String `tfsdk:"factory_address"`
+	ChainID        types.Int64  `tfsdk:"chain_id"`
 }
 
 // MonitorRuleModel describes a rule in the monitor.
 type MonitorRuleModel struct {
 	ID                 types.Int64  `tfsdk:"id"`
+	Key                types.String `tfsdk:"key"`
 	Name               types.String `tfsdk:"name"`
 	Type               types.String `tfsdk:"type"`
 	Threshold          types.Int64  `tfsdk:"threshold"`
 	NotificationPeriod types.Int64  `tfsdk:"notification_period"`
+	CooldownSeconds    types.Int64  `tfsdk:"cooldown_seconds"`
+	DedupWindowSeconds types.Int64  `tfsdk:"dedup_window_seconds"`
+	QuietHours         types.Object `tfsdk:"quiet_hours"`
 	Categories         types.List   `tfsdk:"categories"`
 	Channels           types.Set    `tfsdk:"channels"`
 }
 
+// QuietHoursModel describes the quiet hours of a monitor rule.
+type QuietHoursModel struct {
+	Timezone      types.String `tfsdk:"timezone"`
+	Start         types.String `tfsdk:"start"`
+	End           types.String `tfsdk:"end"`
+	SeverityFloor types.Int64  `tfsdk:"severity_floor"`
+}
+
 // ChannelModel describes a channel in a monitor rule.
 type ChannelModel struct {
-	ID     types.Int64  `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	Params types.String `tfsdk:"params"`
+	ID     types.Int64          `tfsdk:"id"`
+	Name   types.String         `tfsdk:"name"`
+	Params jsontypes.Normalized `tfsdk:"params"`
+}
+
+// WalletModel describes a wallet the monitor is scoped to.
+type WalletModel struct {
+	Address types.String `tfsdk:"address"`
+	ChainID types.Int64  `tfsdk:"chain_id"`
 }
 
+// entityObjectType, entitiesForChainsObjectType, factoryEntityObjectType,
+// channelObjectType, monitorRuleObjectType and walletObjectType are the object
+// types of the entities, entities_for_chains, factory_entities, channels,
+// monitor_rules and wallets attributes.
+var (
+	entityObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"entity_type": types.Int64Type,
+			"params":      jsontypes.NormalizedType{},
+			"address":     types.StringType,
+			"chain_id":    types.Int64Type,
+		},
+	}
+
+	entitiesForChainsObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"entity_type": types.Int64Type,
+			"address":     types.StringType,
+			"chain_ids":   types.SetType{ElemType: types.Int64Type},
+		},
+	}
+
+	quietHoursObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"timezone":       types.StringType,
+			"start":          types.StringType,
+			"end":            types.StringType,
+			"severity_floor": types.Int64Type,
+		},
+	}
+
+	factoryEntityObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"entity_type":     types.Int64Type,
+			"factory_address": types.StringType,
+			"chain_id":        types.Int64Type,
+		},
+	}
+
+	channelObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.Int64Type,
+			"name":   types.StringType,
+			"params": jsontypes.NormalizedType{},
+		},
+	}
+
+	monitorRuleObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":                   types.Int64Type,
+			"key":                  types.StringType,
+			"name":                 types.StringType,
+			"type":                 types.StringType,
+			"threshold":            types.Int64Type,
+			"notification_period":  types.Int64Type,
+			"cooldown_seconds":     types.Int64Type,
+			"dedup_window_seconds": types.Int64Type,
+			"quiet_hours":          quietHoursObjectType,
+			"categories":           types.ListType{ElemType: types.StringType},
+			"channels":             types.SetType{ElemType: channelObjectType},
+		},
+	}
+
+	walletObjectType = types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"address":  types.StringType,
+			"chain_id": types.Int64Type,
+		},
+	}
+)
+
 // Configure adds the provider configured client to the resource.
 func (r *MonitorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -100,159 +274,445 @@ func (r *MonitorResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // ModifyPlan implements resource.ResourceWithModifyPlan.
-// It includes the CustomizeDiff logic for the params attribute.
+// Formatting differences in params are handled by the semantic equality of
+// jsontypes.Normalized. The API also fills in defaults for params that are
+// not configured, so params that are a subset of the state are not a change
+// either.
 func (r *MonitorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Retrieve the plan and state
-	var plan MonitorResourceModel
-	var state MonitorResourceModel
+	// Nothing to compare against during creation, nothing to plan on destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
 
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	var plan, state MonitorResourceModel
+	var configuredParams jsontypes.Normalized
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("params"), &configuredParams)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// State might not exist during creation
-	if !req.State.Raw.IsNull() {
-		diags = req.State.Get(ctx, &state)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	// Alert statistics are only read when enabled
+	if !plan.ReadAlertStats.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("open_alert_count"), types.Int64Null())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_triggered_at"), timetypes.NewRFC3339Null())...)
 	}
 
-	// Check if 'params' attribute requires custom diff logic
-	paramsPath := path.Root("params")
-
-	var planParams, stateParams types.String
+	if params, ok := plannedParams(configuredParams, plan, state); ok {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("params"), params)...)
+
+		// Tell which params the comparison ignored, so that params the
+		// platform added aren't hidden without notice.
+		if keys := ignoredParams(plan.IgnoreParamsArrayOrder, configuredParams, state.Params); len(keys) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("params"),
+				"Monitor Params Differ From Configuration",
+				fmt.Sprintf("The API returns params of monitor %s that are not configured, which are ignored because params_merge_strategy is %q: %s. "+
+					"Add them to params to manage them, or set params_merge_strategy to %q to plan their removal.",
+					state.ID.ValueString(), plan.ParamsMergeStrategy.ValueString(), strings.Join(keys, ", "), paramsMergeStrict),
+			)
+		}
+	}
 
-	diags = req.Plan.GetAttribute(ctx, paramsPath, &planParams)
+	ids, diags := loadServerIDs(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	rules, diags := plannedRules(ctx, plan.MonitorRules, state.MonitorRules, ids)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monitor_rules"), rules)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// State might not exist during creation
-	if !req.State.Raw.IsNull() {
-		diags = req.State.GetAttribute(ctx, paramsPath, &stateParams)
+	// Params that only match the prior params under the params merge
+	// strategy, and channels given by their ID only, which Terraform can't
+	// correlate with their prior state, still made the proposed plan differ
+	// from the state, so all computed attributes became unknown. Plan no
+	// changes when the only differences left are these unknown values.
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if unchanged, diags := planIsUnchanged(ctx, resp.Plan, plan, state, req.State); diags.HasError() {
 		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else {
-		// If state is null (creation), set stateParams to null explicitly
-		// so the checks below don't fail trying to access it.
-		stateParams = types.StringNull()
-		tflog.Debug(ctx, "Skipping params comparison on create")
-		return // Nothing to compare against during creation
+	} else if unchanged {
+		resp.Plan.Raw = req.State.Raw
 	}
+}
 
-	// Proceed only if both plan and state 'params' are known and not null.
-	// If plan params is null/unknown, let TF handle it. If state is null (handled above), skip custom diff.
-	if planParams.IsNull() || planParams.IsUnknown() || stateParams.IsNull() {
-		tflog.Debug(ctx, "Skipping custom diff for params: Plan or State params are null or unknown.")
-		return
+// planIsUnchanged reports whether the plan equals the prior state once the
+// unknown computed attributes take their prior value.
+func planIsUnchanged(ctx context.Context, planned tfsdk.Plan, plan, state MonitorResourceModel, prior tfsdk.State) (bool, diag.Diagnostics) {
+	if plan.ID.IsUnknown() {
+		plan.ID = state.ID
+	}
+	if plan.CreatedBy.IsUnknown() {
+		plan.CreatedBy = state.CreatedBy
+	}
+	if plan.CreatedAt.IsUnknown() {
+		plan.CreatedAt = state.CreatedAt
+	}
+	if plan.UpdatedAt.IsUnknown() {
+		plan.UpdatedAt = state.UpdatedAt
+	}
+	if plan.RuleIDs.IsUnknown() {
+		plan.RuleIDs = state.RuleIDs
+	}
+	if plan.ChannelIDs.IsUnknown() {
+		plan.ChannelIDs = state.ChannelIDs
+	}
+	if plan.DiscoveredAddresses.IsUnknown() {
+		plan.DiscoveredAddresses = state.DiscoveredAddresses
+	}
+	if plan.OpenAlertCount.IsUnknown() {
+		plan.OpenAlertCount = state.OpenAlertCount
+	}
+	if plan.LastTriggeredAt.IsUnknown() {
+		plan.LastTriggeredAt = state.LastTriggeredAt
 	}
 
-	planParamsStr := planParams.ValueString()
-	stateParamsStr := stateParams.ValueString()
+	diags := planned.Set(ctx, &plan)
+	return !diags.HasError() && planned.Raw.Equal(prior.Raw), diags
+}
 
-	// If the strings are identical, no need for deep comparison.
-	if planParamsStr == stateParamsStr {
-		tflog.Debug(ctx, "Params strings are identical, skipping deep comparison.")
-		return
+// plannedParams returns the params to plan instead of the proposed ones, if
+// any. Unconfigured params are left to the server, so they keep their prior
+// value instead of becoming unknown, unless typed params derive them.
+// Configured params that match the prior params under the params merge
+// strategy keep the prior params, so that the difference isn't shown.
+func plannedParams(configured jsontypes.Normalized, plan, state MonitorResourceModel) (jsontypes.Normalized, bool) {
+	if plan.Params.IsUnknown() && configured.IsNull() {
+		if plan.ThresholdParams != nil || plan.AddressParams != nil || plan.TokenParams != nil {
+			return plan.Params, false
+		}
+		return state.Params, true
 	}
 
-	tflog.Debug(ctx, "Params strings differ, performing deep comparison.", map[string]interface{}{
-		"plan_params":  planParamsStr,
-		"state_params": stateParamsStr,
-	})
+	if plan.Params.IsNull() || plan.Params.IsUnknown() || state.Params.IsNull() || state.Params.IsUnknown() {
+		return plan.Params, false
+	}
 
 	var planData, stateData interface{}
+	if plan.Params.Unmarshal(&planData).HasError() || state.Params.Unmarshal(&stateData).HasError() {
+		// Invalid JSON is reported by the attribute's validation.
+		return plan.Params, false
+	}
 
-	errPlan := json.Unmarshal([]byte(planParamsStr), &planData)
-	errState := json.Unmarshal([]byte(stateParamsStr), &stateData)
+	if paramsMatch(plan.ParamsMergeStrategy, plan.IgnoreParamsArrayOrder, planData, stateData) {
+		return state.Params, true
+	}
+	return plan.Params, false
+}
 
-	if errPlan != nil || errState != nil {
-		// If unmarshalling fails, it suggests the strings might not be valid JSON
-		// or the format is unexpected. Log this but let Terraform handle the diff as strings.
-		tflog.Warn(ctx, "Failed to unmarshal params JSON for comparison; falling back to string diff", map[string]interface{}{
-			"plan_error":  errPlan,
-			"state_error": errState,
-		})
-		return
+// ignoredParams returns the keys of the actual params that are not in the
+// configured ones, as dotted paths, in order. Arrays are descended into by
+// index when they have the same length as the configured ones, unless their
+// order is ignored.
+func ignoredParams(ignoreArrayOrder types.Bool, configured, actual jsontypes.Normalized) []string {
+	if configured.IsNull() || configured.IsUnknown() || actual.IsNull() || actual.IsUnknown() {
+		return nil
 	}
 
-	// Compare the unmarshalled data
-	if compareJSONValues(planData, stateData) {
-		tflog.Debug(ctx, "Plan params are a subset of state params; suppressing diff.")
-		// If the plan data is logically contained within the state data, suppress the diff for 'params'.
-		// Use the value read from the state attribute directly
-		resp.Plan.SetAttribute(ctx, paramsPath, stateParams)
-	} else {
-		tflog.Debug(ctx, "Plan params differ logically from state params; allowing diff.")
-		// Otherwise, let the plan proceed as is, allowing Terraform to show the diff.
+	var configuredData, actualData interface{}
+	if configured.Unmarshal(&configuredData).HasError() || actual.Unmarshal(&actualData).HasError() {
+		return nil
 	}
-}
 
-// compareJSONValues recursively compares two unmarshalled JSON values (interface{}).
-// It returns true if `planValue` is logically contained within `stateValue`,
-// meaning all elements in `planValue` exist and match in `stateValue`,
-// but `stateValue` can have additional elements.
-func compareJSONValues(planValue, stateValue interface{}) bool {
-	// Use reflect.DeepEqual for basic types and nil checks
-	if reflect.DeepEqual(planValue, stateValue) {
-		return true
+	var keys []string
+	var collect func(prefix string, configured, actual interface{})
+	collect = func(prefix string, configured, actual interface{}) {
+		switch actual := actual.(type) {
+		case map[string]interface{}:
+			configuredMap, ok := configured.(map[string]interface{})
+			if !ok {
+				return
+			}
+			for key, value := range actual {
+				name := key
+				if prefix != "" {
+					name = prefix + "." + key
+				}
+				if configuredValue, ok := configuredMap[key]; ok {
+					collect(name, configuredValue, value)
+				} else {
+					keys = append(keys, name)
+				}
+			}
+		case []interface{}:
+			configuredSlice, ok := configured.([]interface{})
+			if !ok || len(configuredSlice) != len(actual) || ignoreArrayOrder.ValueBool() {
+				return
+			}
+			for i := range actual {
+				collect(fmt.Sprintf("%s[%d]", prefix, i), configuredSlice[i], actual[i])
+			}
+		}
 	}
+	collect("", configuredData, actualData)
 
-	planMap, planIsMap := planValue.(map[string]interface{})
-	stateMap, stateIsMap := stateValue.(map[string]interface{})
+	slices.Sort(keys)
+	return keys
+}
 
-	planSlice, planIsSlice := planValue.([]interface{})
-	stateSlice, stateIsSlice := stateValue.([]interface{})
+// plannedRules fills in the IDs of planned rules that correspond to prior
+// ones, which the API keeps on update. Rules are matched by key or name, or
+// otherwise by the ID stored for their content, so that renaming them doesn't
+// replace them. IDs of new rules remain unknown. Channels given by their ID
+// only take the name and params of the prior channel with the ID.
+func plannedRules(ctx context.Context, planned, prior types.List, ids serverIDs) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if planned.IsNull() || planned.IsUnknown() || prior.IsNull() || prior.IsUnknown() {
+		return planned, diags
+	}
 
-	// Type mismatch (e.g., map vs slice, map vs scalar)
-	if planIsMap != stateIsMap || planIsSlice != stateIsSlice {
-		return false
+	var plannedRules, priorRules []MonitorRuleModel
+	diags.Append(planned.ElementsAs(ctx, &plannedRules, false)...)
+	diags.Append(prior.ElementsAs(ctx, &priorRules, false)...)
+	if diags.HasError() {
+		return planned, diags
 	}
 
-	if planIsMap {
-		// Compare maps: ensure all keys in planMap exist in stateMap with matching values
-		for key, planSubValue := range planMap {
-			stateSubValue, ok := stateMap[key]
-			if !ok {
-				return false // Key missing in state
+	matched := make([]*MonitorRuleModel, len(plannedRules))
+	for i := range plannedRules {
+		matched[i] = matchRule(plannedRules[i], priorRules)
+	}
+	for i := range plannedRules {
+		if matched[i] == nil {
+			matched[i] = matchRuleContent(plannedRules[i], priorRules, matched, ids)
+		}
+	}
+
+	for i := range plannedRules {
+		rule := &plannedRules[i]
+		priorRule := matched[i]
+		if priorRule == nil {
+			continue
+		}
+		if rule.ID.IsUnknown() {
+			rule.ID = priorRule.ID
+		}
+
+		if rule.Channels.IsNull() || rule.Channels.IsUnknown() || priorRule.Channels.IsNull() || priorRule.Channels.IsUnknown() {
+			continue
+		}
+		var channels, priorChannels []ChannelModel
+		diags.Append(rule.Channels.ElementsAs(ctx, &channels, false)...)
+		diags.Append(priorRule.Channels.ElementsAs(ctx, &priorChannels, false)...)
+		if diags.HasError() {
+			return planned, diags
+		}
+		for j := range channels {
+			channel := &channels[j]
+			if channel.ID.IsNull() || !channel.Name.IsUnknown() || !channel.Params.IsUnknown() {
+				continue
 			}
-			if !compareJSONValues(planSubValue, stateSubValue) {
-				return false // Values differ recursively
+			for _, priorChannel := range priorChannels {
+				if priorChannel.ID.Equal(channel.ID) {
+					channel.Name = priorChannel.Name
+					channel.Params = priorChannel.Params
+					break
+				}
 			}
 		}
-		return true // All plan keys/values found and matched in state
+		channelsValue, channelsDiags := types.SetValueFrom(ctx, channelObjectType, channels)
+		diags.Append(channelsDiags...)
+		if diags.HasError() {
+			return planned, diags
+		}
+		rule.Channels = channelsValue
+	}
+
+	rules, rulesDiags := types.ListValueFrom(ctx, monitorRuleObjectType, plannedRules)
+	diags.Append(rulesDiags...)
+	return rules, diags
+}
+
+// paramsMatch reports whether the actual params satisfy the configured ones
+// under the params_merge_strategy and ignore_params_array_order settings.
+// Unless the strategy is strict, the actual params may contain additional
+// params.
+func paramsMatch(strategy types.String, ignoreArrayOrder types.Bool, configured, actual interface{}) bool {
+	unordered := ignoreArrayOrder.ValueBool()
+	if strategy.ValueString() == paramsMergeStrict {
+		// Only differences in array order are tolerated
+		return unordered && jsonutil.Contains(configured, actual, true) && jsonutil.Contains(actual, configured, true)
+	}
+	return jsonutil.Contains(configured, actual, unordered)
+}
+
+// The values of params_merge_strategy.
+const (
+	paramsMergeStrict = "strict"
+	paramsMergeSubset = "subset"
+	paramsMergeMerge  = "merge"
+)
+
+// mergeJSONValues deep merges override into base. Objects are merged key by
+// key; any other value in override replaces the one in base.
+func mergeJSONValues(base, override interface{}) interface{} {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	overrideMap, overrideIsMap := override.(map[string]interface{})
+	if !baseIsMap || !overrideIsMap {
+		return override
+	}
+
+	merged := make(map[string]interface{}, len(baseMap)+len(overrideMap))
+	for key, value := range baseMap {
+		merged[key] = value
+	}
+	for key, value := range overrideMap {
+		merged[key] = mergeJSONValues(baseMap[key], value)
+	}
+	return merged
+}
+
+// keepPlannedParams returns the planned params instead of the params read
+// back after a write when they match according to paramsMatch. This keeps the
+// new state consistent with the plan when the API fills in defaults, reorders
+// arrays or, with the merge strategy, keeps params set outside Terraform.
+func keepPlannedParams(strategy types.String, ignoreArrayOrder types.Bool, planned, read jsontypes.Normalized) jsontypes.Normalized {
+	if planned.IsNull() || planned.IsUnknown() || read.IsNull() {
+		return read
+	}
+
+	var plannedData, readData interface{}
+	if planned.Unmarshal(&plannedData).HasError() || read.Unmarshal(&readData).HasError() {
+		return read
+	}
+	if paramsMatch(strategy, ignoreArrayOrder, plannedData, readData) {
+		return planned
+	}
+	return read
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig.
+func (r *MonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config MonitorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateEntities(ctx, config)...)
+	resp.Diagnostics.Append(validateChannels(ctx, config)...)
+}
+
+// validateChannels checks that each channel is either given by its ID only,
+// referring to an existing channel, or defined inline by its name and params.
+func validateChannels(ctx context.Context, config MonitorResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.MonitorRules.IsNull() || config.MonitorRules.IsUnknown() {
+		return diags
+	}
+
+	var rules []MonitorRuleModel
+	diags.Append(config.MonitorRules.ElementsAs(ctx, &rules, false)...)
+	if diags.HasError() {
+		return diags
 	}
 
-	if planIsSlice {
-		// Compare slices: must have the same length and elements must match recursively in order
-		// Note: This implements ordered list comparison. If unordered comparison is needed, logic would be more complex.
-		if len(planSlice) != len(stateSlice) {
-			return false
+	for i, rule := range rules {
+		if rule.Channels.IsNull() || rule.Channels.IsUnknown() {
+			continue
+		}
+
+		var channels []ChannelModel
+		diags.Append(rule.Channels.ElementsAs(ctx, &channels, false)...)
+		if diags.HasError() {
+			return diags
 		}
-		for i := range planSlice {
-			if !compareJSONValues(planSlice[i], stateSlice[i]) {
-				return false
+
+		channelsPath := path.Root("monitor_rules").AtListIndex(i).AtName("channels")
+		for _, channel := range channels {
+			if channel.ID.IsUnknown() || channel.Name.IsUnknown() || channel.Params.IsUnknown() {
+				continue
+			}
+
+			switch {
+			case channel.Name.IsNull() != channel.Params.IsNull():
+				diags.AddAttributeError(
+					channelsPath,
+					"Incomplete Channel Definition",
+					"A channel defined inline must configure both name and params. To refer to an existing channel, configure its id only.",
+				)
+			case channel.Name.IsNull() && channel.ID.IsNull():
+				diags.AddAttributeError(
+					channelsPath,
+					"Missing Channel",
+					"A channel must configure either the id of an existing channel, or its name and params.",
+				)
 			}
 		}
-		return true
 	}
 
-	// For scalars (string, number, bool, nil), DeepEqual should have caught matches.
-	// If we reach here, it means scalars differ.
-	return false
+	return diags
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators.
+func (r *MonitorResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("params"),
+			path.MatchRoot("threshold_params"),
+			path.MatchRoot("address_params"),
+			path.MatchRoot("token_params"),
+		),
+		monitorTargets(),
+	}
+}
+
+// validateEntities checks that each entity is configured either by its params
+// or by its address and chain.
+func validateEntities(ctx context.Context, config MonitorResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.Entities.IsNull() || config.Entities.IsUnknown() {
+		return diags
+	}
+
+	var entities []EntityModel
+	diags.Append(config.Entities.ElementsAs(ctx, &entities, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, entity := range entities {
+		if entity.Params.IsUnknown() || entity.Address.IsUnknown() || entity.ChainID.IsUnknown() {
+			continue
+		}
+
+		switch {
+		case !entity.Params.IsNull() && (!entity.Address.IsNull() || !entity.ChainID.IsNull()):
+			diags.AddAttributeError(
+				path.Root("entities"),
+				"Conflicting Entity Params",
+				"An entity can be configured by either params or address and chain_id, not both.",
+			)
+		case entity.Params.IsNull() && entity.Address.IsNull() && entity.ChainID.IsNull():
+			diags.AddAttributeError(
+				path.Root("entities"),
+				"Missing Entity Params",
+				"Each entity must configure either params or address and chain_id.",
+			)
+		case entity.Params.IsNull() && (entity.Address.IsNull() || entity.ChainID.IsNull()):
+			diags.AddAttributeError(
+				path.Root("entities"),
+				"Incomplete Entity Address",
+				"The address and chain_id of an entity must be configured together.",
+			)
+		}
+	}
+
+	return diags
 }
 
 // Schema defines the schema for the resource.
-func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *MonitorResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Hexagate monitor",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -266,37 +726,177 @@ func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			},
 			"monitor_id": schema.Int64Attribute{
 				Optional:    true,
-				Description: "The ID of the monitor type",
+				Computed:    true,
+				Description: "The ID of the monitor type. Changing it recreates the monitor, since the API can't change the type of an existing monitor",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 57),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"clone_from_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of an existing monitor to seed the new monitor from. The monitor type, params and rules that aren't configured are copied from it when the monitor is created. Afterwards the monitor is managed independently, and changing this attribute has no effect",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
 				Description: "A description of the monitor",
 			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The tags of the monitor. When not configured, the tags set in Hexagate are left unchanged",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entity_tags": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The entity tags of the monitor, selecting the entities with these tags. When not configured, the entity tags set in Hexagate are left unchanged",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wallets": schema.ListNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The wallets the monitor is scoped to. When not configured, the wallets set in Hexagate are left unchanged",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Required:    true,
+							Description: "The address of the wallet",
+						},
+						"chain_id": schema.Int64Attribute{
+							Required:    true,
+							Description: "The ID of the chain the wallet is on",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"disabled": schema.BoolAttribute{
-				Required:    true,
-				Description: "Whether the monitor is disabled",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the monitor is disabled. Defaults to false",
+			},
+			"disable_on_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether destroying the resource disables the monitor instead of deleting it, preserving its alert history. Defaults to false",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the monitor is protected from being destroyed. It must be set to false and applied before the monitor can be destroyed. Defaults to false",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the monitor is destroyed even when it has open alerts. Defaults to false",
+			},
+			"params_merge_strategy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(paramsMergeSubset),
+				Description: "How params are reconciled with the params returned by the API. " +
+					"\"strict\" shows any difference as a change. " +
+					"\"subset\" ignores params the API returns in addition to the configured ones. " +
+					"\"merge\" also keeps those params when the monitor is updated. Defaults to \"subset\"",
+				Validators: []validator.String{
+					stringvalidator.OneOf(paramsMergeStrict, paramsMergeSubset, paramsMergeMerge),
+				},
+			},
+			"ignore_params_array_order": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether arrays in params are compared regardless of the order of their elements, so that the API reordering them doesn't show as a change. Defaults to false",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether creating the resource adopts an existing monitor with the same name, updating it to match the configuration, instead of creating another one. Defaults to false",
 			},
 			"params": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
 				Optional:    true,
 				Description: "JSON encoded parameters for the monitor",
 				Computed:    true,
+				Validators: []validator.String{
+					jsonObject(),
+				},
 			},
 			"created_by": schema.StringAttribute{
 				Computed:    true,
 				Description: "The creator of the monitor",
 			},
 			"created_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
 				Computed:    true,
-				Description: "The creation timestamp",
+				Description: "The creation timestamp, in RFC 3339 format",
 			},
 			"updated_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Computed:    true,
+				Description: "The last update timestamp, in RFC 3339 format",
+			},
+			"rule_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "The IDs of the monitor's rules, keyed by rule name",
+			},
+			"channel_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "The IDs of the channels of the monitor's rules, keyed by \"<rule name>/<channel name>\"",
+			},
+			"discovered_addresses": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "The child contracts Hexagate discovered for the factory_entities, keyed by \"<chain_id>/<factory_address>\"",
+			},
+			"read_alert_stats": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether open_alert_count and last_triggered_at are read on every refresh, which takes an additional API request per monitor. Defaults to false",
+			},
+			"open_alert_count": schema.Int64Attribute{
 				Computed:    true,
-				Description: "The last update timestamp",
+				Description: "The number of open alerts raised by the monitor. Only read when read_alert_stats is true",
+			},
+			"last_triggered_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Computed:    true,
+				Description: "When the monitor last raised an alert, in RFC 3339 format. Only read when read_alert_stats is true",
 			},
 		},
 		Blocks: map[string]schema.Block{
-			"entities": schema.ListNestedBlock{
-				Description: "The entities to monitor",
+			"request_options": requestOptionsResourceBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"entities": schema.SetNestedBlock{
+				Description: "The entities to monitor. The order of the entity blocks is irrelevant",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"entity_type": schema.Int64Attribute{
@@ -304,52 +904,181 @@ func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							Description: "The type of the entity",
 						},
 						"params": schema.StringAttribute{
-							Required:    true,
-							Description: "JSON encoded parameters for the entity",
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+							Description: "JSON encoded parameters for the entity. Conflicts with address and chain_id",
+							Validators: []validator.String{
+								jsonObject(),
+							},
+						},
+						"address": schema.StringAttribute{
+							Optional:    true,
+							Description: "The address of the entity, compiled into the params {\"address\": ..., \"chain_id\": ...}. Mixed case addresses must have a valid EIP-55 checksum. Requires chain_id",
+							Validators: []validator.String{
+								addressValue(),
+							},
+						},
+						"chain_id": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The ID of the chain the entity's address is on. Requires address",
 						},
 					},
 				},
 			},
-			"monitor_rules": schema.ListNestedBlock{
-				Description: "The rules for the monitor",
+			"entities_for_chains": schema.SetNestedBlock{
+				Description: "Addresses to monitor on several chains. Each block is expanded into one entity per chain, with the params {\"address\": ..., \"chain_id\": ...}",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
-						"id": schema.Int64Attribute{
-							Computed: true,
-						},
-						"name": schema.StringAttribute{
-							Required: true,
-						},
-						"type": schema.StringAttribute{
-							Required: true,
-						},
-						"threshold": schema.Int64Attribute{
-							Required: true,
-						},
-						"notification_period": schema.Int64Attribute{
-							Optional: true,
+						"entity_type": schema.Int64Attribute{
+							Required:    true,
+							Description: "The type of the entities",
 						},
-						"categories": schema.ListAttribute{
+						"address": schema.StringAttribute{
 							Required:    true,
+							Description: "The address of the entities. Mixed case addresses must have a valid EIP-55 checksum",
+							Validators: []validator.String{
+								addressValue(),
+							},
+						},
+						"chain_ids": schema.SetAttribute{
 							ElementType: types.Int64Type,
+							Required:    true,
+							Description: "The IDs of the chains the address is monitored on",
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
 						},
 					},
-					Blocks: map[string]schema.Block{
-						"channels": schema.SetNestedBlock{
+				},
+			},
+			"factory_entities": schema.SetNestedBlock{
+				Description: "Factory or registry contracts whose child contracts are monitored. Hexagate discovers the children and includes new ones automatically",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.Int64Attribute{
+							Required:    true,
+							Description: "The type of the child entities",
+						},
+						"factory_address": schema.StringAttribute{
+							Required:    true,
+							Description: "The address of the factory or registry contract. Mixed case addresses must have a valid EIP-55 checksum",
+							Validators: []validator.String{
+								addressValue(),
+							},
+						},
+						"chain_id": schema.Int64Attribute{
+							Required:    true,
+							Description: "The ID of the chain the factory is on",
+						},
+					},
+				},
+			},
+			"monitor_rules": schema.ListNestedBlock{
+				Description: "The rules for the monitor",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"key": schema.StringAttribute{
+							Optional:    true,
+							Description: "A stable identifier of the rule within the monitor, used instead of the name to match the rule to its server-side counterpart, so that renaming the rule updates it in place",
+						},
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"type": schema.StringAttribute{
+							Required: true,
+						},
+						"threshold": schema.Int64Attribute{
+							Required: true,
+							Validators: []validator.Int64{
+								int64validator.OneOf(10, 30, 50, 70, 90),
+							},
+						},
+						"notification_period": schema.Int64Attribute{
+							Optional: true,
+						},
+						"cooldown_seconds": schema.Int64Attribute{
+							Optional:    true,
+							Description: "How long, in seconds, the rule stays silent after notifying, so that repeated firings don't flood its channels",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"dedup_window_seconds": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The window, in seconds, within which repeated firings of the same detection are rolled up into a single notification",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"quiet_hours": schema.SingleNestedAttribute{
+							Optional:    true,
+							Description: "A daily window in which notifications of the rule are batched instead of sent, unless their severity is at least severity_floor",
+							Attributes: map[string]schema.Attribute{
+								"timezone": schema.StringAttribute{
+									Required:    true,
+									Description: "The IANA time zone of start and end, e.g. \"Europe/London\"",
+									Validators: []validator.String{
+										timezoneValue(),
+									},
+								},
+								"start": schema.StringAttribute{
+									Required:    true,
+									Description: "The time of day the quiet hours start, as \"HH:MM\"",
+									Validators: []validator.String{
+										stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day as \"HH:MM\""),
+									},
+								},
+								"end": schema.StringAttribute{
+									Required:    true,
+									Description: "The time of day the quiet hours end, as \"HH:MM\". An end before the start spans midnight",
+									Validators: []validator.String{
+										stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day as \"HH:MM\""),
+									},
+								},
+								"severity_floor": schema.Int64Attribute{
+									Optional:    true,
+									Description: "The severity from which alerts are still sent immediately during the quiet hours. One of 10, 30, 50, 70 or 90",
+									Validators: []validator.Int64{
+										int64validator.OneOf(10, 30, 50, 70, 90),
+									},
+								},
+							},
+						},
+						"categories": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Description: "The categories of alerts the rule notifies about, each given by its ID or its name",
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(categoryValue()),
+							},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"channels": schema.SetNestedBlock{
 							Description: "The notification channels for the rule",
 							NestedObject: schema.NestedBlockObject{
 								Attributes: map[string]schema.Attribute{
 									"id": schema.Int64Attribute{
-										Optional: true,
-										Computed: true,
+										Optional:    true,
+										Description: "The ID of an existing channel to refer to, instead of defining the channel by its name and params. The IDs of channels defined by their name and params are exported in channel_ids",
 									},
 									"name": schema.StringAttribute{
-										Required: true,
+										Optional:    true,
+										Computed:    true,
+										Description: "The name of the channel. Read from the API when the channel is given by its id only",
 									},
 									"params": schema.StringAttribute{
-										Required:    true,
-										Description: "JSON encoded parameters for the channel",
+										CustomType:  jsontypes.NormalizedType{},
+										Optional:    true,
+										Computed:    true,
+										Description: "JSON encoded parameters for the channel. Read from the API when the channel is given by its id only",
 										Sensitive:   true,
+										Validators: []validator.String{
+											jsonObject(),
+										},
 									},
 								},
 							},
@@ -359,9 +1088,16 @@ func (r *MonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			},
 		},
 	}
+
+	for name, attribute := range typedParamsSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
 	var plan MonitorResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -369,39 +1105,305 @@ func (r *MonitorResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	monitor := monitorFromModel(ctx, plan)
-	if monitor == nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Monitor",
-			"Failed to convert plan to monitor data.",
-		)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultMonitorCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	result, err := r.client.HexagateClient.CreateMonitor(monitor)
-	if err != nil {
-		resp.Diagnostics.AddError(
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	monitor, diags := monitorFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyDefaultTags(monitor)
+
+	client, diags := r.client.HexagateClient.WithRequestOptions(plan.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.CloneFromID.IsNull() {
+		resp.Diagnostics.Append(cloneMonitor(ctx, client, plan, monitor)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	categories := newCategoryResolver(client)
+	if err := categories.resolveMonitor(ctx, monitor); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("monitor_rules"),
 			"Error Creating Monitor",
-			fmt.Sprintf("Could not create monitor: %s", err),
+			fmt.Sprintf("Could not resolve categories: %s", err),
 		)
 		return
 	}
 
+	var result *CreateMonitorResponse
+	if plan.AdoptExisting.ValueBool() {
+		id, diags := r.adopt(ctx, client, monitor)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if id != 0 {
+			result = &CreateMonitorResponse{ID: id}
+		}
+	}
+
+	if result == nil {
+		createStarted := time.Now()
+		var err error
+		result, err = client.CreateMonitor(ctx, monitor)
+		// Without an ID in the response, the created monitor can only be
+		// found by name, as after an ambiguous error.
+		missingID := err == nil && (result == nil || result.ID == 0)
+		if missingID {
+			err = errors.New("the response does not include the ID of the created monitor")
+		}
+		if err != nil {
+			if !missingID && !isAmbiguousError(ctx, err) {
+				appendAPIErrorDiagnostics(&resp.Diagnostics, "Error Creating Monitor", "Could not create monitor", err, monitorAttributes...)
+				return
+			}
+
+			// The monitor may have been created even though the request failed.
+			// Look for it by name so it is tracked in state instead of duplicated
+			// by the next apply.
+			id, diags := reconcileCreatedMonitor(ctx, client, monitor.Name, createStarted, err)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			result = &CreateMonitorResponse{ID: id}
+		}
+	}
+
 	plan.ID = types.StringValue(strconv.Itoa(result.ID))
 
 	// Read the response into the state
-	diags = r.read(ctx, &plan)
+	plannedParams := plan.Params
+	found, diags := r.read(ctx, &plan, false)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor",
+			fmt.Sprintf("Monitor ID %s was not found after it was written.", plan.ID.ValueString()),
+		)
+		return
+	}
+	plan.Params = keepPlannedParams(plan.ParamsMergeStrategy, plan.IgnoreParamsArrayOrder, plannedParams, plan.Params)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(saveServerIDs(ctx, resp.Private, plan)...)
+}
+
+// cloneMonitor seeds a new monitor from the monitor given by clone_from_id.
+// The monitor type and params are copied unless they are configured, and the
+// rules unless any rule is configured. Rule and channel IDs are cleared, so
+// that the copies are independent of the source monitor.
+func cloneMonitor(ctx context.Context, client MonitorAPI, plan MonitorResourceModel, monitor *Monitor) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id := int(plan.CloneFromID.ValueInt64())
+	source, err := client.GetMonitor(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("clone_from_id"),
+				"Error Creating Monitor",
+				fmt.Sprintf("Monitor ID %d to clone from does not exist.", id),
+			)
+			return diags
+		}
+		appendAPIErrorDiagnostics(&diags, "Error Creating Monitor", fmt.Sprintf("Could not read monitor ID %d to clone from", id), err)
+		return diags
+	}
+
+	if plan.MonitorID.IsNull() || plan.MonitorID.IsUnknown() {
+		monitor.MonitorID = source.MonitorID
+	}
+	if (plan.Params.IsNull() || plan.Params.IsUnknown()) && plan.ThresholdParams == nil && plan.AddressParams == nil && plan.TokenParams == nil {
+		monitor.Params = source.Params
+	}
+	if len(monitor.MonitorRules) == 0 {
+		monitor.MonitorRules = make([]Rule, len(source.MonitorRules))
+		for i, rule := range source.MonitorRules {
+			rule.ID = 0
+			rule.Channels = append([]Channel(nil), rule.Channels...)
+			for j := range rule.Channels {
+				rule.Channels[j].ID = 0
+			}
+			monitor.MonitorRules[i] = rule
+		}
+	}
+
+	tflog.Info(ctx, "Cloning monitor", map[string]interface{}{"source_id": id})
+	return diags
+}
+
+// hasUntrackedClonedRules reports whether the rules of the monitor were copied
+// by clone_from_id and aren't tracked in state because no rules are
+// configured.
+func (m MonitorResourceModel) hasUntrackedClonedRules() bool {
+	return !m.CloneFromID.IsNull() && !m.MonitorRules.IsNull() && len(m.MonitorRules.Elements()) == 0
+}
+
+// replaceMonitor replaces the whole monitor. Rules copied by clone_from_id
+// that aren't tracked in the prior state are kept.
+func replaceMonitor(ctx context.Context, client MonitorAPI, id int, prior MonitorResourceModel, monitor *Monitor) error {
+	if prior.hasUntrackedClonedRules() && len(monitor.MonitorRules) == 0 {
+		current, err := client.GetMonitor(ctx, id)
+		if err != nil {
+			return err
+		}
+		monitor.MonitorRules = current.MonitorRules
+	}
+	return client.UpdateMonitor(ctx, id, monitor)
+}
+
+// isAmbiguousError reports whether err leaves it unknown if the request took
+// effect server-side, i.e. the response was lost or the server failed.
+func isAmbiguousError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrReadOnly) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// adopt updates the existing monitor with the same name as the planned
+// monitor to match it, for resources with adopt_existing set. Rules and
+// channels are matched to the existing ones by name, so that they are
+// updated rather than replaced. It returns 0 if there is no such monitor.
+func (r *MonitorResource) adopt(ctx context.Context, client MonitorAPI, monitor *Monitor) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Creating Monitor",
+			fmt.Sprintf("Could not list monitors to look for an existing monitor to adopt: %s", err),
+		)
+		return 0, diags
+	}
+
+	existing := monitorsNamed(monitors, monitor.Name)
+	switch len(existing) {
+	case 0:
+		return 0, diags
+	case 1:
+	default:
+		diags.AddError(
+			"Error Creating Monitor",
+			fmt.Sprintf("Could not adopt an existing monitor: %d monitors are named %q. Import one of them instead.", len(existing), monitor.Name),
+		)
+		return 0, diags
+	}
+
+	id := existing[0].ID
+	for i := range monitor.MonitorRules {
+		rule := &monitor.MonitorRules[i]
+		for _, existingRule := range existing[0].MonitorRules {
+			if existingRule.Name != rule.Name {
+				continue
+			}
+			rule.ID = existingRule.ID
+			for j := range rule.Channels {
+				for _, existingChannel := range existingRule.Channels {
+					if existingChannel.Name == rule.Channels[j].Name {
+						rule.Channels[j].ID = existingChannel.ID
+						break
+					}
+				}
+			}
+			break
+		}
+	}
+
+	tflog.Info(ctx, "Adopting existing monitor", map[string]interface{}{"id": id, "name": monitor.Name})
+
+	if err := client.UpdateMonitor(ctx, id, monitor); err != nil {
+		appendAPIErrorDiagnostics(&diags, "Error Creating Monitor", fmt.Sprintf("Could not update existing monitor ID %d to adopt it", id), err, monitorAttributes...)
+		return 0, diags
+	}
+
+	return id, diags
+}
+
+// monitorsNamed returns the monitors with the given name.
+func monitorsNamed(monitors []*Monitor, name string) []*Monitor {
+	var named []*Monitor
+	for _, monitor := range monitors {
+		if monitor.Name == name {
+			named = append(named, monitor)
+		}
+	}
+	return named
+}
+
+// reconcileCreatedMonitor looks for a monitor named name that was created
+// after started, returning its ID. It fails unless exactly one such monitor exists.
+func reconcileCreatedMonitor(ctx context.Context, client MonitorAPI, name string, started time.Time, createErr error) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Creating Monitor",
+			fmt.Sprintf("Could not create monitor: %s\n\nThe monitor may have been created anyway, but listing monitors to check failed: %s", createErr, err),
+		)
+		return 0, diags
+	}
+
+	var candidates []int
+	for _, m := range monitors {
+		if m.Name != name {
+			continue
+		}
+		// Allow for clock skew between us and the API.
+		createdAt, err := parseTimestamp(m.CreatedAt)
+		if err != nil || createdAt.Before(started.Add(-time.Minute)) {
+			continue
+		}
+		candidates = append(candidates, m.ID)
+	}
+
+	if len(candidates) != 1 {
+		diags.AddError(
+			"Error Creating Monitor",
+			fmt.Sprintf("Could not create monitor: %s\n\nFound %d monitors named %q created since the request was sent; "+
+				"if one of them was created by this apply, import it with \"terraform import\" before applying again.", createErr, len(candidates), name),
+		)
+		return 0, diags
+	}
+
+	diags.AddWarning(
+		"Monitor Created Despite Error",
+		fmt.Sprintf("The create request failed (%s), but monitor ID %d named %q was created by it and is now tracked in state.", createErr, candidates[0], name),
+	)
+	return candidates[0], diags
 }
 
 func (r *MonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
 	var state MonitorResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -409,17 +1411,38 @@ func (r *MonitorResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	diags = r.read(ctx, &state)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultMonitorReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	found, diags := r.read(ctx, &state, r.client.BulkRead)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The monitor was deleted outside Terraform, e.g. in the Hexagate UI.
+	// Removing it from state makes Terraform plan to create it again.
+	if !found {
+		tflog.Warn(ctx, "Monitor not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(saveServerIDs(ctx, resp.Private, state)...)
 }
 
-func (r *MonitorResource) read(ctx context.Context, state *MonitorResourceModel) diag.Diagnostics {
+// read refreshes the model from the API. With bulk set, the monitor is looked
+// up in the memoized list of all monitors instead of being fetched on its own.
+// It reports whether the monitor exists; a missing monitor is not an error.
+func (r *MonitorResource) read(ctx context.Context, state *MonitorResourceModel, bulk bool) (bool, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	id, err := strconv.Atoi(state.ID.ValueString())
@@ -428,16 +1451,38 @@ func (r *MonitorResource) read(ctx context.Context, state *MonitorResourceModel)
 			"Error Reading Monitor",
 			fmt.Sprintf("Could not parse ID: %s", err),
 		)
-		return diags
+		return true, diags
 	}
 
-	monitor, err := r.client.HexagateClient.GetMonitor(id)
+	client, clientDiags := r.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	diags.Append(clientDiags...)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	var monitor *Monitor
+	if bulk {
+		monitor, err = findMonitor(ctx, client, id)
+	}
+	if monitor == nil && err == nil {
+		monitor, err = client.GetMonitor(ctx, id)
+	}
+	if isNotFound(err) {
+		return false, diags
+	}
 	if err != nil {
 		diags.AddError(
 			"Error Reading Monitor",
 			fmt.Sprintf("Could not read monitor ID %d: %s", id, err),
 		)
-		return diags
+		return true, diags
+	}
+	if err := checkMonitorResponse(monitor); err != nil {
+		diags.AddError(
+			"Error Reading Monitor",
+			fmt.Sprintf("The API returned an incomplete response for monitor ID %d: %s. The state is left unchanged; try again later.", id, err),
+		)
+		return true, diags
 	}
 
 	// Set the ID explicitly
@@ -445,150 +1490,304 @@ func (r *MonitorResource) read(ctx context.Context, state *MonitorResourceModel)
 
 	// Map response to model
 	state.Name = types.StringValue(monitor.Name)
-	state.MonitorID = types.Int64Value(int64(monitor.MonitorID))
-	state.Description = types.StringValue(monitor.Description)
+	// The API omits monitor_id for monitors without a type, which is not a
+	// valid value to configure
+	state.MonitorID = types.Int64Null()
+	if monitor.MonitorID != 0 {
+		state.MonitorID = types.Int64Value(int64(monitor.MonitorID))
+	}
+	state.Description = optionalStringValue(monitor.Description, state.Description)
+	// A monitor the API returns without the disabled field is enabled, which
+	// matches the attribute's default, so omitting it never shows as drift
 	state.Disabled = types.BoolValue(monitor.Disabled)
 	state.CreatedBy = types.StringValue(monitor.CreatedBy)
-	state.CreatedAt = types.StringValue(monitor.CreatedAt)
-	state.UpdatedAt = types.StringValue(monitor.UpdatedAt)
+	// Not known to the API, e.g. after import
+	if state.DisableOnDestroy.IsNull() {
+		state.DisableOnDestroy = types.BoolValue(false)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+	if state.ForceDelete.IsNull() {
+		state.ForceDelete = types.BoolValue(false)
+	}
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(false)
+	}
+	if state.ParamsMergeStrategy.IsNull() {
+		state.ParamsMergeStrategy = types.StringValue(paramsMergeSubset)
+	}
+	if state.IgnoreParamsArrayOrder.IsNull() {
+		state.IgnoreParamsArrayOrder = types.BoolValue(false)
+	}
+	state.CreatedAt = timestampValue(monitor.CreatedAt)
+	state.UpdatedAt = timestampValue(monitor.UpdatedAt)
+
+	if state.ReadAlertStats.IsNull() {
+		state.ReadAlertStats = types.BoolValue(false)
+	}
+	state.OpenAlertCount = types.Int64Null()
+	state.LastTriggeredAt = timetypes.NewRFC3339Null()
+	if state.ReadAlertStats.ValueBool() {
+		// The statistics are informational, so failing to read them
+		// doesn't fail the refresh
+		summary, err := client.GetAlertSummary(ctx, id)
+		if err != nil {
+			diags.AddWarning(
+				"Error Reading Alert Statistics",
+				fmt.Sprintf("Could not read the alert statistics of monitor ID %d: %s", id, err),
+			)
+		} else {
+			state.OpenAlertCount = types.Int64Value(int64(summary.OpenCount))
+			state.LastTriggeredAt = timestampValue(summary.LastTriggeredAt)
+		}
+	}
+
+	// Default tags are added on every write, so they are only kept in state
+	// when they are configured on the monitor itself
+	var priorTags []string
+	if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
+		diags.Append(state.Tags.ElementsAs(ctx, &priorTags, false)...)
+	}
+	tags := make([]string, 0, len(monitor.MonitorTags))
+	for _, tag := range monitor.MonitorTags {
+		if slices.Contains(r.client.DefaultTags, tag) && !slices.Contains(priorTags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	tagsValue, tagsDiags := types.SetValueFrom(ctx, types.StringType, tags)
+	diags.Append(tagsDiags...)
+	entityTagsValue, tagsDiags := types.SetValueFrom(ctx, types.StringType, append([]string{}, monitor.EntitiesTags...))
+	diags.Append(tagsDiags...)
+	if diags.HasError() {
+		return true, diags
+	}
+	state.Tags = tagsValue
+	state.EntityTags = entityTagsValue
+
+	wallets := make([]WalletModel, len(monitor.Wallets))
+	for i, wallet := range monitor.Wallets {
+		wallets[i] = WalletModel{
+			Address: types.StringValue(wallet.Address),
+			ChainID: types.Int64Value(int64(wallet.ChainID)),
+		}
+	}
+	walletsValue, walletsDiags := types.ListValueFrom(ctx, walletObjectType, wallets)
+	diags.Append(walletsDiags...)
+	if diags.HasError() {
+		return true, diags
+	}
+	state.Wallets = walletsValue
 
 	// Handle entities
 	if monitor.Entities != nil {
-		entities := make([]EntityModel, len(monitor.Entities))
-		for i, e := range monitor.Entities {
-			entityMap := e.(map[string]interface{})
-			params, _ := json.Marshal(entityMap["params"])
+		// Entities that were expanded from an entities_for_chains block are
+		// collected back into it, so that they don't show up as entities.
+		var priorGroups []EntitiesForChainsModel
+		if !state.EntitiesForChains.IsNull() && !state.EntitiesForChains.IsUnknown() {
+			diags.Append(state.EntitiesForChains.ElementsAs(ctx, &priorGroups, false)...)
+		}
+		var priorFactories []FactoryEntityModel
+		if !state.FactoryEntities.IsNull() && !state.FactoryEntities.IsUnknown() {
+			diags.Append(state.FactoryEntities.ElementsAs(ctx, &priorFactories, false)...)
+		}
+		remaining, factories := collectFactoryEntities(monitor.Entities, priorFactories)
+		factoriesValue, factoriesDiags := types.SetValueFrom(ctx, factoryEntityObjectType, factories)
+		diags.Append(factoriesDiags...)
+		if diags.HasError() {
+			return true, diags
+		}
+		state.FactoryEntities = factoriesValue
+
+		remaining, groups := collectEntitiesForChains(remaining, priorGroups)
+		groupsValue, groupsDiags := types.SetValueFrom(ctx, entitiesForChainsObjectType, groups)
+		diags.Append(groupsDiags...)
+		if diags.HasError() {
+			return true, diags
+		}
+		state.EntitiesForChains = groupsValue
+
+		// Keep the prior params of entities whose params are unchanged, so that
+		// formatting differences don't make the set elements differ.
+		var prior []EntityModel
+		if !state.Entities.IsNull() && !state.Entities.IsUnknown() {
+			diags.Append(state.Entities.ElementsAs(ctx, &prior, false)...)
+		}
+
+		entities := make([]EntityModel, len(remaining))
+		for i, e := range remaining {
 			entities[i] = EntityModel{
-				EntityType: types.Int64Value(int64(entityMap["entity_type"].(float64))),
-				Params:     types.StringValue(string(params)),
+				EntityType: types.Int64Value(int64(e.EntityType)),
+				Params:     paramsValue(e.Params),
+				Address:    types.StringNull(),
+				ChainID:    types.Int64Null(),
+			}
+			for _, p := range prior {
+				if !p.EntityType.Equal(entities[i].EntityType) {
+					continue
+				}
+				if jsonEqual(p.Params, entities[i].Params) {
+					entities[i].Params = p.Params
+					break
+				}
+				if p.Params.IsNull() && entityAddressMatches(e.Params, p) {
+					entities[i].Params = jsontypes.NewNormalizedNull()
+					entities[i].Address = p.Address
+					entities[i].ChainID = p.ChainID
+					break
+				}
 			}
 		}
-		state.Entities, diags = types.ListValueFrom(ctx, types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"entity_type": types.Int64Type,
-				"params":      types.StringType,
-			},
-		}, entities)
+		entitiesValue, entitiesDiags := types.SetValueFrom(ctx, entityObjectType, entities)
+		diags.Append(entitiesDiags...)
 		if diags.HasError() {
-			return diags
+			return true, diags
 		}
+		state.Entities = entitiesValue
 	}
 
-	// Handle monitor rules
-	if monitor.MonitorRules != nil {
+	ruleIDs := make(map[string]int64, len(monitor.MonitorRules))
+	channelIDs := make(map[string]int64)
+	for _, rule := range monitor.MonitorRules {
+		ruleIDs[rule.Name] = int64(rule.ID)
+		for _, channel := range rule.Channels {
+			channelIDs[rule.Name+"/"+channel.Name] = int64(channel.ID)
+		}
+	}
+	discovered := map[string][]string{}
+	for _, entity := range monitor.Entities {
+		if factory, chainID, ok := factoryEntity(entity.Params); ok {
+			discovered[fmt.Sprintf("%d/%s", chainID, factory)] = append([]string{}, entity.DiscoveredAddresses...)
+		}
+	}
+	discoveredValue, discoveredDiags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, discovered)
+	diags.Append(discoveredDiags...)
+	state.DiscoveredAddresses = discoveredValue
+
+	ruleIDsValue, idsDiags := types.MapValueFrom(ctx, types.Int64Type, ruleIDs)
+	diags.Append(idsDiags...)
+	channelIDsValue, idsDiags := types.MapValueFrom(ctx, types.Int64Type, channelIDs)
+	diags.Append(idsDiags...)
+	if diags.HasError() {
+		return true, diags
+	}
+	state.RuleIDs = ruleIDsValue
+	state.ChannelIDs = channelIDsValue
+
+	// Handle monitor rules. Rules copied by clone_from_id are not tracked
+	// until rules are configured, since Terraform doesn't allow the provider to
+	// add blocks to the configured ones.
+	if monitor.MonitorRules != nil && !state.hasUntrackedClonedRules() {
+		// Keys only exist in Terraform, so carry them over from the prior rules
+		var prior []MonitorRuleModel
+		if !state.MonitorRules.IsNull() && !state.MonitorRules.IsUnknown() {
+			diags.Append(state.MonitorRules.ElementsAs(ctx, &prior, false)...)
+		}
+		priorRules := matchPriorRules(monitor.MonitorRules, prior)
+		categories := newCategoryResolver(client)
+
 		rules := make([]MonitorRuleModel, len(monitor.MonitorRules))
-		for i, r := range monitor.MonitorRules {
-			ruleMap := r.(map[string]interface{})
-
-			// Ensure we set the rule ID from the API response
-			ruleID := int64(ruleMap["id"].(float64))
-
-			// Handle channels
-			channels := make([]ChannelModel, 0)
-			if channelsRaw, ok := ruleMap["channels"].([]interface{}); ok {
-				for _, ch := range channelsRaw {
-					channel := ch.(map[string]interface{})
-					params, _ := json.Marshal(channel["params"])
-					channels = append(channels, ChannelModel{
-						ID:     types.Int64Value(int64(channel["id"].(float64))),
-						Name:   types.StringValue(channel["name"].(string)),
-						Params: types.StringValue(string(params)),
-					})
-				}
+		for i, rule := range monitor.MonitorRules {
+			// Handle channels. Only channels that refer to an existing
+			// channel keep their ID in the set, so that the set elements
+			// don't change when the API assigns IDs; the IDs of the other
+			// channels are in channel_ids.
+			var priorChannels []ChannelModel
+			if priorRules[i] != nil && !priorRules[i].Channels.IsNull() && !priorRules[i].Channels.IsUnknown() {
+				diags.Append(priorRules[i].Channels.ElementsAs(ctx, &priorChannels, false)...)
 			}
-
-			// Convert categories
-			categories := make([]int64, 0)
-			if cats, ok := ruleMap["categories"].([]interface{}); ok {
-				for _, c := range cats {
-					categories = append(categories, int64(c.(float64)))
+			channels := make([]ChannelModel, 0, len(rule.Channels))
+			for _, channel := range rule.Channels {
+				model := ChannelModel{
+					ID:     types.Int64Null(),
+					Name:   types.StringValue(channel.Name),
+					Params: paramsValue(channel.Params),
 				}
+				for _, priorChannel := range priorChannels {
+					if priorChannel.ID.Equal(types.Int64Value(int64(channel.ID))) {
+						model.ID = priorChannel.ID
+						break
+					}
+				}
+				channels = append(channels, model)
 			}
 
-			// Convert categories to []attr.Value
-			categoryValues := make([]attr.Value, len(categories))
-			for i, cat := range categories {
-				categoryValues[i] = types.Int64Value(cat)
+			// Categories configured by name keep their name
+			priorCategories := types.ListNull(types.StringType)
+			if priorRules[i] != nil {
+				priorCategories = priorRules[i].Categories
+			}
+			categoriesValue, err := categories.categoriesValue(ctx, rule.Categories, priorCategories)
+			if err != nil {
+				diags.AddError(
+					"Error Reading Monitor",
+					fmt.Sprintf("Could not read the categories of rule %q of monitor ID %d: %s", rule.Name, id, err),
+				)
+				return true, diags
 			}
 
-			channelsValue, diags := types.SetValueFrom(ctx, types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"id":     types.Int64Type,
-					"name":   types.StringType,
-					"params": types.StringType,
-				},
-			}, channels)
+			channelsValue, channelsDiags := types.SetValueFrom(ctx, channelObjectType, channels)
+			diags.Append(channelsDiags...)
 			if diags.HasError() {
-				return diags
+				return true, diags
 			}
 
 			rules[i] = MonitorRuleModel{
-				ID:        types.Int64Value(ruleID),
-				Name:      types.StringValue(ruleMap["name"].(string)),
+				ID:        types.Int64Value(int64(rule.ID)),
+				Key:       types.StringNull(),
+				Name:      types.StringValue(rule.Name),
 				Type:      types.StringValue("notification"),
-				Threshold: types.Int64Value(int64(ruleMap["threshold"].(float64))),
+				Threshold: types.Int64Value(int64(rule.Threshold)),
 			}
 
 			// Set notification_period if it exists in the response
-			if notificationPeriod, ok := ruleMap["notification_period"].(float64); ok {
-				rules[i].NotificationPeriod = types.Int64Value(int64(notificationPeriod))
+			if rule.NotificationPeriod != nil {
+				rules[i].NotificationPeriod = types.Int64Value(int64(*rule.NotificationPeriod))
+			}
+			if rule.CooldownSeconds != nil {
+				rules[i].CooldownSeconds = types.Int64Value(int64(*rule.CooldownSeconds))
+			}
+			if rule.DedupWindowSeconds != nil {
+				rules[i].DedupWindowSeconds = types.Int64Value(int64(*rule.DedupWindowSeconds))
 			}
+			rules[i].QuietHours = quietHoursValue(rule.QuietHours)
 
-			rules[i].Categories = types.ListValueMust(types.Int64Type, categoryValues)
+			if priorRules[i] != nil {
+				rules[i].Key = priorRules[i].Key
+			}
+
+			rules[i].Categories = categoriesValue
 			rules[i].Channels = channelsValue
 		}
-		state.MonitorRules, diags = types.ListValueFrom(ctx, types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"id":                  types.Int64Type,
-				"name":                types.StringType,
-				"type":                types.StringType,
-				"threshold":           types.Int64Type,
-				"notification_period": types.Int64Type,
-				"categories":          types.ListType{ElemType: types.Int64Type},
-				"channels": types.SetType{
-					ElemType: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"id":     types.Int64Type,
-							"name":   types.StringType,
-							"params": types.StringType,
-						},
-					},
-				},
-			},
-		}, rules)
+		rulesValue, rulesDiags := types.ListValueFrom(ctx, monitorRuleObjectType, rules)
+		diags.Append(rulesDiags...)
 		if diags.HasError() {
-			return diags
+			return true, diags
 		}
+		state.MonitorRules = rulesValue
 	}
 
 	if monitor.Params != nil {
-		// Normalize JSON before storing to potentially reduce superficial diffs
-		paramsBytes, err := json.Marshal(monitor.Params)
+		params, err := json.Marshal(monitor.Params)
 		if err != nil {
 			diags.AddError("Error Marshalling Params", fmt.Sprintf("Could not marshal params from API: %s", err))
-			return diags
-		}
-		// Unmarshal and remarshal to get a canonical form (e.g., sorted keys)
-		var tempParams interface{}
-		if err := json.Unmarshal(paramsBytes, &tempParams); err != nil {
-			diags.AddError("Error Unmarshalling Params", fmt.Sprintf("Could not unmarshal params for normalization: %s", err))
-			return diags
-		}
-		normalizedParamsBytes, err := json.Marshal(tempParams)
-		if err != nil {
-			diags.AddError("Error Re-marshalling Params", fmt.Sprintf("Could not marshal normalized params: %s", err))
-			return diags
+			return true, diags
 		}
-		state.Params = types.StringValue(string(normalizedParamsBytes))
+		state.Params = jsontypes.NewNormalizedValue(string(params))
+		refreshTypedParams(state, monitor.Params)
 	} else {
 		// Ensure Params is explicitly null if not returned by API
-		state.Params = types.StringNull()
+		state.Params = jsontypes.NewNormalizedNull()
 	}
 
-	return diags
+	return true, diags
 }
 
 func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
 	var state MonitorResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -603,6 +1802,15 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultMonitorUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Preserve IDs from state while applying updates from plan
 	plan.ID = state.ID
 
@@ -612,39 +1820,15 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
 		plan.MonitorRules.ElementsAs(ctx, &planRules, false)
 		state.MonitorRules.ElementsAs(ctx, &stateRules, false)
 
-		// Match rules by name and preserve IDs
+		// Match rules by key, or by name for rules without one, and preserve IDs
 		for i := range planRules {
-			for _, stateRule := range stateRules {
-				if planRules[i].Name.ValueString() == stateRule.Name.ValueString() {
-					planRules[i].ID = stateRule.ID
-					break
-				}
+			if stateRule := matchRule(planRules[i], stateRules); stateRule != nil {
+				planRules[i].ID = stateRule.ID
 			}
 		}
 
-		// Create a proper object type for monitor rules
-		monitorRuleObject := types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"id":                  types.Int64Type,
-				"name":                types.StringType,
-				"type":                types.StringType,
-				"threshold":           types.Int64Type,
-				"notification_period": types.Int64Type,
-				"categories":          types.ListType{ElemType: types.Int64Type},
-				"channels": types.SetType{
-					ElemType: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"id":     types.Int64Type,
-							"name":   types.StringType,
-							"params": types.StringType,
-						},
-					},
-				},
-			},
-		}
-
 		// Update plan.MonitorRules with preserved IDs
-		newRules, diags := types.ListValueFrom(ctx, monitorRuleObject, planRules)
+		newRules, diags := types.ListValueFrom(ctx, monitorRuleObjectType, planRules)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -652,14 +1836,19 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
 		plan.MonitorRules = newRules
 	}
 
-	monitor := monitorFromModel(ctx, plan)
-	if monitor == nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Monitor",
-			"Failed to convert plan to monitor data.",
-		)
+	monitor, diags := monitorFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the channels the rules already have instead of creating them again
+	ids, diags := loadServerIDs(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	assignChannelIDs(ctx, monitor, state, ids)
 
 	id, err := strconv.Atoi(plan.ID.ValueString())
 	if err != nil {
@@ -670,27 +1859,96 @@ func (r *MonitorResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	if err := r.client.HexagateClient.UpdateMonitor(id, monitor); err != nil {
-		resp.Diagnostics.AddError(
+	r.applyDefaultTags(monitor)
+
+	// Keep the params set outside Terraform, which the state holds since the
+	// last refresh
+	if plan.ParamsMergeStrategy.ValueString() == paramsMergeMerge && !state.Params.IsNull() && monitor.Params != nil {
+		var stateParams map[string]interface{}
+		if !state.Params.Unmarshal(&stateParams).HasError() {
+			monitor.Params = mergeJSONValues(stateParams, monitor.Params).(map[string]interface{})
+		}
+	}
+
+	client, diags := r.client.HexagateClient.WithRequestOptions(plan.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories := newCategoryResolver(client)
+	if err := categories.resolveMonitor(ctx, monitor); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("monitor_rules"),
 			"Error Updating Monitor",
-			fmt.Sprintf("Could not update monitor ID %d: %s", id, err),
+			fmt.Sprintf("Could not resolve categories: %s", err),
 		)
 		return
 	}
 
+	ctx, diags = checkMonitorUnmodified(ctx, client, id, state.UpdatedAt)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the fields that changed, so that fields managed by the server
+	// or edited concurrently elsewhere are left alone. Fall back to replacing
+	// the whole monitor if the prior state can't be converted or the API
+	// doesn't support partial updates.
+	var fields map[string]interface{}
+	if prior, diags := monitorFromModel(ctx, state); !diags.HasError() && categories.resolveMonitor(ctx, prior) == nil {
+		assignChannelIDs(ctx, prior, state, ids)
+		r.applyDefaultTags(prior)
+		fields, err = monitorChanges(prior, monitor)
+	}
+
+	switch {
+	case fields == nil || err != nil:
+		err = replaceMonitor(ctx, client, id, state, monitor)
+	case len(fields) > 0:
+		err = client.PatchMonitor(ctx, id, fields)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+			tflog.Debug(ctx, "Partial updates are not supported by the API, replacing the monitor")
+			err = replaceMonitor(ctx, client, id, state, monitor)
+		}
+	}
+	if isPreconditionFailed(err) {
+		resp.Diagnostics.Append(monitorModifiedDiagnostic(id, ""))
+		return
+	}
+	if err != nil {
+		appendAPIErrorDiagnostics(&resp.Diagnostics, "Error Updating Monitor", fmt.Sprintf("Could not update monitor ID %d", id), err, monitorAttributes...)
+		return
+	}
+
 	// Read the response into the state
-	diags = r.read(ctx, &plan)
+	plannedParams := plan.Params
+	found, diags := r.read(ctx, &plan, false)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor",
+			fmt.Sprintf("Monitor ID %s was not found after it was written.", plan.ID.ValueString()),
+		)
+		return
+	}
+	plan.Params = keepPlannedParams(plan.ParamsMergeStrategy, plan.IgnoreParamsArrayOrder, plannedParams, plan.Params)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(saveServerIDs(ctx, resp.Private, plan)...)
 }
 
 func (r *MonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
 	var state MonitorResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -698,6 +1956,29 @@ func (r *MonitorResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultMonitorDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Monitor Is Protected From Deletion",
+			fmt.Sprintf("Monitor %q has deletion_protection enabled. Set deletion_protection = false and apply before destroying it.", state.Name.ValueString()),
+		)
+		return
+	}
+
+	diags = r.client.CheckDestroyAllowed(fmt.Sprintf("monitor %q", state.Name.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	id, err := strconv.Atoi(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -707,7 +1988,37 @@ func (r *MonitorResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	if err := r.client.HexagateClient.DeleteMonitor(id); err != nil {
+	client, diags := r.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, diags = checkMonitorUnmodified(ctx, client, id, state.UpdatedAt)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.ForceDelete.ValueBool() {
+		resp.Diagnostics.Append(checkNoOpenAlerts(ctx, client, id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.DisableOnDestroy.ValueBool() {
+		r.disable(ctx, client, id, state, &resp.Diagnostics)
+		return
+	}
+
+	// A monitor that was already deleted outside Terraform is gone either way.
+	if err := client.DeleteMonitor(ctx, id); isNotFound(err) {
+		return
+	} else if isPreconditionFailed(err) {
+		resp.Diagnostics.Append(monitorModifiedDiagnostic(id, ""))
+		return
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Monitor",
 			fmt.Sprintf("Could not delete monitor ID %d: %s", id, err),
@@ -716,139 +2027,754 @@ func (r *MonitorResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ImportState imports a monitor by its numeric ID, or by its name when the
+// import ID has the form name=<monitor name>.
 func (r *MonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	name, byName := strings.CutPrefix(req.ID, "name=")
+	if !byName {
+		if _, err := strconv.Atoi(req.ID); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected a numeric monitor ID or name=<monitor name>, got: %q", req.ID),
+			)
+			return
+		}
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	monitors, err := r.client.HexagateClient.GetAllMonitors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Monitor",
+			fmt.Sprintf("Could not list monitors: %s", err),
+		)
+		return
+	}
+
+	var ids []string
+	for _, monitor := range monitorsNamed(monitors, name) {
+		ids = append(ids, strconv.Itoa(monitor.ID))
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Error Importing Monitor",
+			fmt.Sprintf("No monitor named %q exists", name),
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Error Importing Monitor",
+			fmt.Sprintf("%d monitors are named %q (IDs %s). Import one of them by its ID instead.", len(ids), name, strings.Join(ids, ", ")),
+		)
+	}
 }
 
-// Helper function to convert from the model to the API format
-func monitorFromModel(ctx context.Context, model MonitorResourceModel) map[string]interface{} {
-	monitor := map[string]interface{}{
-		"name":          model.Name.ValueString(),
-		"disabled":      model.Disabled.ValueBool(),
-		"wallets":       []interface{}{},
-		"monitor_tags":  []interface{}{},
-		"entities_tags": []interface{}{},
+// checkNoOpenAlerts returns an error diagnostic when the monitor has open
+// alerts, so that a monitor that is firing during an incident isn't destroyed
+// by accident. Alerts that were resolved don't block the destroy, however
+// recent they are.
+func checkNoOpenAlerts(ctx context.Context, client MonitorAPI, id int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	summary, err := client.GetAlertSummary(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Deleting Monitor",
+			fmt.Sprintf("Could not check monitor ID %d for open alerts: %s\n\nSet force_delete = true and apply to destroy the monitor without the check.", id, err),
+		)
+		return diags
+	}
+
+	if summary.OpenCount > 0 {
+		diags.AddError(
+			"Monitor Has Open Alerts",
+			fmt.Sprintf("Monitor ID %d has %d open alerts and may be firing during an incident. Set force_delete = true and apply to destroy it anyway.", id, summary.OpenCount),
+		)
+	}
+
+	return diags
+}
+
+// disable disables the monitor instead of deleting it, for resources with
+// disable_on_destroy set.
+func (r *MonitorResource) disable(ctx context.Context, client MonitorAPI, id int, state MonitorResourceModel, diags *diag.Diagnostics) {
+	err := client.PatchMonitor(ctx, id, map[string]interface{}{"disabled": true})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+		tflog.Debug(ctx, "Partial updates are not supported by the API, replacing the monitor")
+		monitor, monitorDiags := monitorFromModel(ctx, state)
+		diags.Append(monitorDiags...)
+		if diags.HasError() {
+			return
+		}
+		assignChannelIDs(ctx, monitor, state, serverIDs{})
+		if err := newCategoryResolver(client).resolveMonitor(ctx, monitor); err != nil {
+			diags.AddError(
+				"Error Disabling Monitor",
+				fmt.Sprintf("Could not resolve categories: %s", err),
+			)
+			return
+		}
+		r.applyDefaultTags(monitor)
+		monitor.Disabled = true
+		err = replaceMonitor(ctx, client, id, state, monitor)
+	}
+	if isNotFound(err) {
+		return
+	}
+	if isPreconditionFailed(err) {
+		diags.Append(monitorModifiedDiagnostic(id, ""))
+		return
+	}
+	if err != nil {
+		diags.AddError(
+			"Error Disabling Monitor",
+			fmt.Sprintf("Could not disable monitor ID %d: %s", id, err),
+		)
+	}
+}
+
+// applyDefaultTags merges the provider's default tags into the monitor's tags.
+func (r *MonitorResource) applyDefaultTags(monitor *Monitor) {
+	for _, tag := range r.client.DefaultTags {
+		if !slices.Contains(monitor.MonitorTags, tag) {
+			monitor.MonitorTags = append(monitor.MonitorTags, tag)
+		}
+	}
+}
+
+// monitorFromModel converts the model into the API format. Params that can't
+// be decoded are reported with the attribute they were read from.
+func monitorFromModel(ctx context.Context, model MonitorResourceModel) (*Monitor, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	monitor := &Monitor{
+		Name:         model.Name.ValueString(),
+		Disabled:     model.Disabled.ValueBool(),
+		Entities:     []Entity{},
+		Wallets:      []Wallet{},
+		MonitorTags:  []string{},
+		EntitiesTags: []string{},
+		MonitorRules: []Rule{},
 	}
 
 	if !model.ID.IsNull() && model.ID.ValueString() != "" {
-		monitor["id"] = model.ID.ValueString()
+		id, err := strconv.Atoi(model.ID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("id"), "Invalid Monitor ID", fmt.Sprintf("Could not parse ID %q: %s", model.ID.ValueString(), err))
+			return nil, diags
+		}
+		monitor.ID = id
 	}
 
 	if !model.MonitorID.IsNull() {
-		monitor["monitor_id"] = model.MonitorID.ValueInt64()
+		monitor.MonitorID = int(model.MonitorID.ValueInt64())
 	}
 
 	if !model.Description.IsNull() {
-		monitor["description"] = model.Description.ValueString()
+		monitor.Description = model.Description.ValueString()
+	}
+
+	if !model.Tags.IsNull() && !model.Tags.IsUnknown() {
+		diags.Append(model.Tags.ElementsAs(ctx, &monitor.MonitorTags, false)...)
+	}
+
+	if !model.EntityTags.IsNull() && !model.EntityTags.IsUnknown() {
+		diags.Append(model.EntityTags.ElementsAs(ctx, &monitor.EntitiesTags, false)...)
+	}
+
+	if !model.Wallets.IsNull() && !model.Wallets.IsUnknown() {
+		var wallets []WalletModel
+		diags.Append(model.Wallets.ElementsAs(ctx, &wallets, false)...)
+
+		for _, wallet := range wallets {
+			monitor.Wallets = append(monitor.Wallets, Wallet{
+				Address: wallet.Address.ValueString(),
+				ChainID: int(wallet.ChainID.ValueInt64()),
+			})
+		}
 	}
 
 	// Handle entities
 	if !model.Entities.IsNull() {
 		var entities []EntityModel
-		model.Entities.ElementsAs(ctx, &entities, false)
+		diags.Append(model.Entities.ElementsAs(ctx, &entities, false)...)
 
-		apiEntities := make([]map[string]interface{}, len(entities))
 		for i, entity := range entities {
 			var params map[string]interface{}
-			err := json.Unmarshal([]byte(entity.Params.ValueString()), &params)
-			if err != nil {
-				log.Printf("[ERROR] Error unmarshalling params: %s", err)
-				return nil
+			if entity.Params.IsNull() {
+				params = map[string]interface{}{
+					"address":  entity.Address.ValueString(),
+					"chain_id": entity.ChainID.ValueInt64(),
+				}
+			} else if err := json.Unmarshal([]byte(entity.Params.ValueString()), &params); err != nil {
+				diags.AddAttributeError(
+					path.Root("entities"),
+					"Invalid Entity Params",
+					fmt.Sprintf("Could not decode the params of entity %d (entity_type %d): %s", i, entity.EntityType.ValueInt64(), err),
+				)
+				continue
 			}
 
-			apiEntities[i] = map[string]interface{}{
-				"entity_type": entity.EntityType.ValueInt64(),
-				"params":      params,
+			monitor.Entities = append(monitor.Entities, Entity{
+				EntityType: int(entity.EntityType.ValueInt64()),
+				Params:     params,
+			})
+		}
+	}
+
+	if !model.FactoryEntities.IsNull() {
+		var factories []FactoryEntityModel
+		diags.Append(model.FactoryEntities.ElementsAs(ctx, &factories, false)...)
+
+		for _, factory := range factories {
+			monitor.Entities = append(monitor.Entities, Entity{
+				EntityType: int(factory.EntityType.ValueInt64()),
+				Params: map[string]interface{}{
+					"factory_address":   factory.FactoryAddress.ValueString(),
+					"chain_id":          factory.ChainID.ValueInt64(),
+					"discover_children": true,
+				},
+			})
+		}
+	}
+
+	if !model.EntitiesForChains.IsNull() {
+		var groups []EntitiesForChainsModel
+		diags.Append(model.EntitiesForChains.ElementsAs(ctx, &groups, false)...)
+
+		for _, group := range groups {
+			var chainIDs []int64
+			diags.Append(group.ChainIDs.ElementsAs(ctx, &chainIDs, false)...)
+			slices.Sort(chainIDs)
+
+			for _, chainID := range chainIDs {
+				monitor.Entities = append(monitor.Entities, Entity{
+					EntityType: int(group.EntityType.ValueInt64()),
+					Params: map[string]interface{}{
+						"address":  group.Address.ValueString(),
+						"chain_id": chainID,
+					},
+				})
 			}
 		}
-		monitor["entities"] = apiEntities
-	} else {
-		monitor["entities"] = []interface{}{}
 	}
 
 	// Handle monitor rules
 	if !model.MonitorRules.IsNull() {
 		var rules []MonitorRuleModel
-		model.MonitorRules.ElementsAs(ctx, &rules, false)
+		diags.Append(model.MonitorRules.ElementsAs(ctx, &rules, false)...)
 
-		apiRules := make([]map[string]interface{}, len(rules))
 		for i, rule := range rules {
 			var channels []ChannelModel
-			rule.Channels.ElementsAs(ctx, &channels, false)
+			diags.Append(rule.Channels.ElementsAs(ctx, &channels, false)...)
 
-			apiChannels := make([]map[string]interface{}, len(channels))
+			apiChannels := make([]Channel, len(channels))
 			for j, channel := range channels {
-				var params map[string]interface{}
-				err := json.Unmarshal([]byte(channel.Params.ValueString()), &params)
-				if err != nil {
-					log.Printf("[ERROR] Error unmarshalling params: %s", err)
-					return nil
+				if !channel.ID.IsNull() && !channel.ID.IsUnknown() {
+					apiChannels[j].ID = int(channel.ID.ValueInt64())
 				}
 
-				apiChannels[j] = map[string]interface{}{
-					"name":   channel.Name.ValueString(),
-					"params": params,
+				// Channels given by their ID only refer to an existing channel
+				if channel.Params.IsNull() || channel.Params.IsUnknown() {
+					continue
 				}
 
-				if !channel.ID.IsNull() {
-					apiChannels[j]["id"] = channel.ID.ValueInt64()
+				var params map[string]interface{}
+				err := json.Unmarshal([]byte(channel.Params.ValueString()), &params)
+				if err != nil {
+					// The error is left out of the detail, since it may quote
+					// the params, which are sensitive.
+					diags.AddAttributeError(
+						path.Root("monitor_rules").AtListIndex(i).AtName("channels"),
+						"Invalid Channel Params",
+						fmt.Sprintf("Could not decode the params of channel %d (%q) of rule %q as a JSON object.", j, channel.Name.ValueString(), rule.Name.ValueString()),
+					)
+					continue
 				}
+				apiChannels[j].Name = channel.Name.ValueString()
+				apiChannels[j].Params = params
 			}
 
-			var categories []int64
-			rule.Categories.ElementsAs(ctx, &categories, false)
+			var categories []string
+			diags.Append(rule.Categories.ElementsAs(ctx, &categories, false)...)
 
-			apiRules[i] = map[string]interface{}{
-				"name":       rule.Name.ValueString(),
-				"type":       rule.Type.ValueString(),
-				"threshold":  rule.Threshold.ValueInt64(),
-				"categories": categories,
-				"channels":   apiChannels,
+			apiRule := Rule{
+				Name:       rule.Name.ValueString(),
+				Type:       rule.Type.ValueString(),
+				Threshold:  int(rule.Threshold.ValueInt64()),
+				Categories: make([]int, 0, len(categories)),
+				Channels:   apiChannels,
+			}
+
+			// Categories given by name are resolved by a categoryResolver
+			for _, category := range categories {
+				id, err := strconv.Atoi(category)
+				if err != nil {
+					apiRule.Categories = nil
+					apiRule.categoryNames = categories
+					break
+				}
+				apiRule.Categories = append(apiRule.Categories, id)
 			}
 
 			// Add notification_period if not null
 			if !rule.NotificationPeriod.IsNull() {
-				apiRules[i]["notification_period"] = rule.NotificationPeriod.ValueInt64()
+				notificationPeriod := int(rule.NotificationPeriod.ValueInt64())
+				apiRule.NotificationPeriod = &notificationPeriod
+			}
+			if !rule.CooldownSeconds.IsNull() {
+				cooldown := int(rule.CooldownSeconds.ValueInt64())
+				apiRule.CooldownSeconds = &cooldown
+			}
+			if !rule.DedupWindowSeconds.IsNull() {
+				dedupWindow := int(rule.DedupWindowSeconds.ValueInt64())
+				apiRule.DedupWindowSeconds = &dedupWindow
+			}
+			if !rule.QuietHours.IsNull() {
+				var quietHours QuietHoursModel
+				diags.Append(rule.QuietHours.As(ctx, &quietHours, basetypes.ObjectAsOptions{})...)
+				apiRule.QuietHours = &QuietHours{
+					Timezone: quietHours.Timezone.ValueString(),
+					Start:    quietHours.Start.ValueString(),
+					End:      quietHours.End.ValueString(),
+				}
+				if !quietHours.SeverityFloor.IsNull() {
+					severityFloor := int(quietHours.SeverityFloor.ValueInt64())
+					apiRule.QuietHours.SeverityFloor = &severityFloor
+				}
 			}
 
 			if !rule.ID.IsNull() && rule.ID.ValueInt64() != 0 {
-				apiRules[i]["id"] = rule.ID.ValueInt64()
+				apiRule.ID = int(rule.ID.ValueInt64())
 			}
+
+			monitor.MonitorRules = append(monitor.MonitorRules, apiRule)
 		}
-		monitor["monitor_rules"] = apiRules
 	}
 
 	// Handle params
 	if !model.Params.IsNull() && !model.Params.IsUnknown() {
 		var params map[string]interface{}
-		// Normalize the JSON string from the config/plan before sending
-		paramsStr := model.Params.ValueString()
-		var tempParams interface{}
-		if err := json.Unmarshal([]byte(paramsStr), &tempParams); err != nil {
-			// This might happen if the string is not valid JSON, though schema validation should catch this.
-			log.Printf("[WARN] Error unmarshalling params from model for normalization: %s. Sending raw string value.", err)
-			// Attempt to send raw if unmarshalling fails, though the API might reject it.
-			// Or return nil / add diagnostic? For now, log warning and proceed.
-			// It's better to let the API call fail than to silently corrupt data.
-			// Let's add a diagnostic and return nil for safety.
-			// --> Returning nil seems safer. Add diagnostic elsewhere if needed.
-			// log.Printf("[ERROR] Invalid JSON in params attribute: %s", err)
-			// Need diags object here to add error. Modify function signature?
-			// For now, just return nil as before.
-			return nil
-		}
-		normalizedParamsBytes, err := json.Marshal(tempParams)
-		if err != nil {
-			log.Printf("[ERROR] Error marshalling normalized params: %s", err)
-			return nil
+		if err := json.Unmarshal([]byte(model.Params.ValueString()), &params); err != nil {
+			diags.AddAttributeError(path.Root("params"), "Invalid Monitor Params", fmt.Sprintf("Could not decode the params as a JSON object: %s", err))
+		}
+		monitor.Params = params
+	}
+
+	params, typedDiags := typedParams(ctx, model)
+	diags.Append(typedDiags...)
+	if params != nil {
+		monitor.Params = params
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return monitor, diags
+}
+
+// serverManagedFields are never sent in partial updates.
+var serverManagedFields = []string{"id", "created_by", "created_at", "updated_at"}
+
+// monitorChanges returns the top-level fields of the monitor that differ
+// between prior and planned, mapped to their planned values. Fields that are
+// omitted from the planned monitor are mapped to nil to clear them.
+func monitorChanges(prior, planned *Monitor) (map[string]interface{}, error) {
+	priorFields, err := monitorFields(prior)
+	if err != nil {
+		return nil, err
+	}
+	plannedFields, err := monitorFields(planned)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	for field, value := range plannedFields {
+		if !reflect.DeepEqual(priorFields[field], value) {
+			changes[field] = value
+		}
+	}
+	for field := range priorFields {
+		// Params are optional and computed, so unconfigured params are left
+		// to the server rather than cleared.
+		if _, ok := plannedFields[field]; !ok && field != "params" {
+			changes[field] = nil
+		}
+	}
+	for _, field := range serverManagedFields {
+		delete(changes, field)
+	}
+
+	return changes, nil
+}
+
+func monitorFields(monitor *Monitor) (map[string]interface{}, error) {
+	data, err := json.Marshal(monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// checkMonitorResponse checks that a monitor returned by the API has the IDs
+// the state relies on. A monitor, rule or channel without an ID would
+// otherwise be stored with ID 0, and the next update would create the rules
+// and channels again.
+func checkMonitorResponse(monitor *Monitor) error {
+	if monitor.ID == 0 {
+		return errors.New("the monitor has no ID")
+	}
+	for i, rule := range monitor.MonitorRules {
+		if rule.ID == 0 {
+			return fmt.Errorf("rule %d (%q) has no ID", i, rule.Name)
+		}
+		for j, channel := range rule.Channels {
+			if channel.ID == 0 {
+				return fmt.Errorf("channel %d (%q) of rule %q has no ID", j, channel.Name, rule.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// findMonitor looks up a monitor in the list of all monitors, returning nil
+// if it isn't listed.
+func findMonitor(ctx context.Context, client MonitorAPI, id int) (*Monitor, error) {
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, monitor := range monitors {
+		if monitor.ID == id {
+			return monitor, nil
+		}
+	}
+	return nil, nil
+}
+
+// optionalStringValue returns the value of an optional string attribute read
+// from the API. The API doesn't distinguish an empty string from an unset
+// one, so an empty string is null when the prior value is null, and an empty
+// string otherwise, so that neither shows as a change.
+func optionalStringValue(value string, prior types.String) types.String {
+	if value == "" && (prior.IsNull() || prior.IsUnknown()) {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// paramsValue converts the params of an entity or channel returned by the API
+// into their attribute value. Missing params become an empty object, so that
+// configuration generated on import passes validation.
+func paramsValue(params map[string]interface{}) jsontypes.Normalized {
+	if params == nil {
+		return jsontypes.NewNormalizedValue("{}")
+	}
+	encoded, _ := json.Marshal(params)
+	return jsontypes.NewNormalizedValue(string(encoded))
+}
+
+// entityAddressMatches reports whether the params of an entity returned by the
+// API are the ones compiled from the address and chain_id of a configured
+// entity. Addresses are compared regardless of their case.
+func entityAddressMatches(params map[string]interface{}, entity EntityModel) bool {
+	address, chainID, ok := entityAddress(params)
+	return ok && strings.EqualFold(address, entity.Address.ValueString()) && chainID == entity.ChainID.ValueInt64()
+}
+
+// entityAddress returns the address and chain ID of entity params that consist
+// of exactly these two keys, as compiled from an address and chain.
+func entityAddress(params map[string]interface{}) (string, int64, bool) {
+	if len(params) != 2 {
+		return "", 0, false
+	}
+	address, ok := params["address"].(string)
+	if !ok {
+		return "", 0, false
+	}
+	chainID, ok := params["chain_id"].(float64)
+	if !ok || chainID != float64(int64(chainID)) {
+		return "", 0, false
+	}
+	return address, int64(chainID), true
+}
+
+// factoryEntity returns the factory address and chain ID of entity params that
+// instruct Hexagate to discover the children of a factory contract.
+func factoryEntity(params map[string]interface{}) (string, int64, bool) {
+	if len(params) != 3 || params["discover_children"] != true {
+		return "", 0, false
+	}
+	factory, ok := params["factory_address"].(string)
+	if !ok {
+		return "", 0, false
+	}
+	chainID, ok := params["chain_id"].(float64)
+	if !ok || chainID != float64(int64(chainID)) {
+		return "", 0, false
+	}
+	return factory, int64(chainID), true
+}
+
+// collectFactoryEntities separates the factory entities returned by the API
+// from the other entities. Factory addresses keep the case of the matching
+// prior factory entity.
+func collectFactoryEntities(entities []Entity, prior []FactoryEntityModel) ([]Entity, []FactoryEntityModel) {
+	remaining := []Entity{}
+	factories := []FactoryEntityModel{}
+
+	for _, entity := range entities {
+		address, chainID, ok := factoryEntity(entity.Params)
+		if !ok {
+			remaining = append(remaining, entity)
+			continue
+		}
+
+		factory := FactoryEntityModel{
+			EntityType:     types.Int64Value(int64(entity.EntityType)),
+			FactoryAddress: types.StringValue(address),
+			ChainID:        types.Int64Value(chainID),
+		}
+		for _, p := range prior {
+			if p.EntityType.Equal(factory.EntityType) && p.ChainID.Equal(factory.ChainID) && strings.EqualFold(p.FactoryAddress.ValueString(), address) {
+				factory.FactoryAddress = p.FactoryAddress
+				break
+			}
+		}
+		factories = append(factories, factory)
+	}
+	return remaining, factories
+}
+
+// collectEntitiesForChains collects the entities returned by the API that
+// belong to one of the prior entities_for_chains blocks back into the block.
+// A block keeps the chains it is still found on, and is dropped when it isn't
+// found on any. The entities that don't belong to a block are returned.
+func collectEntitiesForChains(entities []Entity, prior []EntitiesForChainsModel) ([]Entity, []EntitiesForChainsModel) {
+	consumed := make([]bool, len(entities))
+	groups := []EntitiesForChainsModel{}
+
+	for _, group := range prior {
+		if group.Address.IsNull() || group.Address.IsUnknown() || group.ChainIDs.IsNull() || group.ChainIDs.IsUnknown() {
+			continue
+		}
+
+		wanted := map[int64]bool{}
+		for _, element := range group.ChainIDs.Elements() {
+			if chainID, ok := element.(types.Int64); ok {
+				wanted[chainID.ValueInt64()] = true
+			}
+		}
+
+		var found []attr.Value
+		for i, entity := range entities {
+			if consumed[i] || int64(entity.EntityType) != group.EntityType.ValueInt64() {
+				continue
+			}
+			address, chainID, ok := entityAddress(entity.Params)
+			if !ok || !wanted[chainID] || !strings.EqualFold(address, group.Address.ValueString()) {
+				continue
+			}
+			consumed[i] = true
+			delete(wanted, chainID)
+			found = append(found, types.Int64Value(chainID))
+		}
+
+		if len(found) > 0 {
+			group.ChainIDs = types.SetValueMust(types.Int64Type, found)
+			groups = append(groups, group)
+		}
+	}
+
+	remaining := []Entity{}
+	for i, entity := range entities {
+		if !consumed[i] {
+			remaining = append(remaining, entity)
+		}
+	}
+	return remaining, groups
+}
+
+// quietHoursValue converts the quiet hours of a rule returned by the API into
+// the quiet_hours attribute.
+func quietHoursValue(quietHours *QuietHours) types.Object {
+	if quietHours == nil {
+		return types.ObjectNull(quietHoursObjectType.AttrTypes)
+	}
+
+	severityFloor := types.Int64Null()
+	if quietHours.SeverityFloor != nil {
+		severityFloor = types.Int64Value(int64(*quietHours.SeverityFloor))
+	}
+	return types.ObjectValueMust(quietHoursObjectType.AttrTypes, map[string]attr.Value{
+		"timezone":       types.StringValue(quietHours.Timezone),
+		"start":          types.StringValue(quietHours.Start),
+		"end":            types.StringValue(quietHours.End),
+		"severity_floor": severityFloor,
+	})
+}
+
+// jsonEqual reports whether two JSON values are semantically equal.
+func jsonEqual(a, b jsontypes.Normalized) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return false
+	}
+
+	equal, diags := a.StringSemanticEquals(context.Background(), b)
+	return equal && !diags.HasError()
+}
+
+// matchRule finds the prior rule that a planned rule corresponds to. Rules
+// with a key match the prior rule with the same key, or a prior rule without
+// a key that has the same name, so that adding a key doesn't replace the
+// rule. Rules without a key match by name.
+func matchRule(planned MonitorRuleModel, prior []MonitorRuleModel) *MonitorRuleModel {
+	if !planned.Key.IsNull() && !planned.Key.IsUnknown() {
+		for i := range prior {
+			if prior[i].Key.Equal(planned.Key) {
+				return &prior[i]
+			}
 		}
-		// Now unmarshal the *normalized* bytes into the map for the API call
-		if err := json.Unmarshal(normalizedParamsBytes, &params); err != nil {
-			log.Printf("[ERROR] Error unmarshalling normalized params into map: %s", err)
-			return nil
+	}
+
+	for i := range prior {
+		if prior[i].Key.IsNull() && prior[i].Name.ValueString() == planned.Name.ValueString() {
+			return &prior[i]
+		}
+	}
+
+	return nil
+}
+
+// matchRuleContent finds the prior rule with the ID stored for the content of
+// a planned rule, unless another planned rule already matches it.
+func matchRuleContent(planned MonitorRuleModel, prior []MonitorRuleModel, matched []*MonitorRuleModel, ids serverIDs) *MonitorRuleModel {
+	hash, ok := ruleHash(planned)
+	if !ok {
+		return nil
+	}
+	id, ok := ids.Rules[hash]
+	if !ok {
+		return nil
+	}
+
+	for i := range prior {
+		if !prior[i].ID.IsNull() && prior[i].ID.ValueInt64() == id {
+			if slices.Contains(matched, &prior[i]) {
+				return nil
+			}
+			return &prior[i]
+		}
+	}
+	return nil
+}
+
+// assignChannelIDs sets the IDs of the channels defined in the rules of a
+// monitor, which the state only has in channel_ids. The channels of a rule
+// keep the ID of the prior channel with the same name in the prior rule with
+// the same ID or, failing that, the ID stored for their content.
+func assignChannelIDs(ctx context.Context, monitor *Monitor, prior MonitorResourceModel, ids serverIDs) {
+	if prior.RuleIDs.IsNull() || prior.RuleIDs.IsUnknown() || prior.ChannelIDs.IsNull() || prior.ChannelIDs.IsUnknown() {
+		return
+	}
+	var ruleIDs, channelIDs map[string]int64
+	if prior.RuleIDs.ElementsAs(ctx, &ruleIDs, false).HasError() || prior.ChannelIDs.ElementsAs(ctx, &channelIDs, false).HasError() {
+		return
+	}
+	ruleNames := make(map[int64]string, len(ruleIDs))
+	for name, id := range ruleIDs {
+		ruleNames[id] = name
+	}
+
+	for i := range monitor.MonitorRules {
+		rule := &monitor.MonitorRules[i]
+		name, ok := ruleNames[int64(rule.ID)]
+		if rule.ID == 0 || !ok {
+			continue
+		}
+
+		// The IDs of the prior channels of the rule, and whether a channel
+		// already has them
+		priorIDs := map[int64]bool{}
+		for key, id := range channelIDs {
+			if strings.HasPrefix(key, name+"/") {
+				priorIDs[id] = false
+			}
+		}
+		for _, channel := range rule.Channels {
+			if _, ok := priorIDs[int64(channel.ID)]; ok {
+				priorIDs[int64(channel.ID)] = true
+			}
+		}
+
+		for j := range rule.Channels {
+			channel := &rule.Channels[j]
+			if id, ok := channelIDs[name+"/"+channel.Name]; ok && channel.ID == 0 && !priorIDs[id] {
+				channel.ID = int(id)
+				priorIDs[id] = true
+			}
+		}
+		for j := range rule.Channels {
+			channel := &rule.Channels[j]
+			if channel.ID != 0 || channel.Params == nil {
+				continue
+			}
+			hash, ok := paramsHash(channel.Params)
+			if !ok {
+				continue
+			}
+			if id, ok := ids.Channels[hash]; ok {
+				if used, prior := priorIDs[id]; prior && !used {
+					channel.ID = int(id)
+					priorIDs[id] = true
+				}
+			}
+		}
+	}
+}
+
+// matchPriorRules returns the prior rule of each rule returned by the API:
+// the prior rule with the same ID or, for rules that were just created and
+// have no ID in the prior rules yet, the same name. Rules without a prior
+// rule are nil.
+func matchPriorRules(rules []Rule, prior []MonitorRuleModel) []*MonitorRuleModel {
+	matched := make([]*MonitorRuleModel, len(rules))
+	used := make([]bool, len(prior))
+
+	for i, rule := range rules {
+		for j, p := range prior {
+			if !used[j] && !p.ID.IsUnknown() && !p.ID.IsNull() && p.ID.ValueInt64() == int64(rule.ID) {
+				matched[i], used[j] = &prior[j], true
+				break
+			}
+		}
+	}
+
+	for i, rule := range rules {
+		if matched[i] != nil {
+			continue
+		}
+		for j, p := range prior {
+			if !used[j] && (p.ID.IsUnknown() || p.ID.IsNull()) && p.Name.ValueString() == rule.Name {
+				matched[i], used[j] = &prior[j], true
+				break
+			}
 		}
-		monitor["params"] = params
 	}
 
-	return monitor
+	return matched
 }
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// payloadMonitorAPI serves a fixed JSON payload as every monitor, decoded the
+// way HexagateClient decodes monitor responses.
+type payloadMonitorAPI struct {
+	*FakeMonitorAPI
+	payload []byte
+}
+
+func (a *payloadMonitorAPI) WithRequestOptions(opts *RequestOptionsModel) (MonitorAPI, diag.Diagnostics) {
+	_, diags := a.FakeMonitorAPI.WithRequestOptions(opts)
+	return a, diags
+}
+
+func (a *payloadMonitorAPI) GetMonitor(_ context.Context, _ int) (*Monitor, error) {
+	var monitor Monitor
+	if err := json.Unmarshal(a.payload, &monitor); err != nil {
+		return nil, err
+	}
+	return &monitor, nil
+}
+
+func TestCheckMonitorResponse(t *testing.T) {
+	tests := map[string]struct {
+		monitor *Monitor
+		wantErr bool
+	}{
+		"complete": {
+			monitor: &Monitor{ID: 1, MonitorRules: []Rule{{ID: 2, Channels: []Channel{{ID: 3}}}}},
+		},
+		"no rules": {
+			monitor: &Monitor{ID: 1},
+		},
+		"monitor without ID": {
+			monitor: &Monitor{MonitorRules: []Rule{{ID: 2}}},
+			wantErr: true,
+		},
+		"rule without ID": {
+			monitor: &Monitor{ID: 1, MonitorRules: []Rule{{ID: 2}, {Name: "second"}}},
+			wantErr: true,
+		},
+		"channel without ID": {
+			monitor: &Monitor{ID: 1, MonitorRules: []Rule{{ID: 2, Channels: []Channel{{ID: 3}, {Name: "slack"}}}}},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkMonitorResponse(test.monitor)
+			if (err != nil) != test.wantErr {
+				t.Errorf("checkMonitorResponse() error = %v, want error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// FuzzCheckMonitorResponse reads monitors from malformed payloads. Reading
+// must not panic, and must fail without setting the state whenever the
+// payload lacks an ID the state relies on.
+func FuzzCheckMonitorResponse(f *testing.F) {
+	seeds := []string{
+		`{"id":1,"name":"m","monitor_id":2,"monitor_rules":[{"id":3,"name":"r","type":"notification","threshold":50,"categories":[1],"channels":[{"id":4,"name":"c","params":{"url":"https://example.com"}}]}]}`,
+		`{"id":1,"name":"m"}`,
+		`{"name":"m","monitor_rules":[]}`,
+		`{"id":1,"monitor_rules":[{"name":"r"}]}`,
+		`{"id":1,"monitor_rules":[{"id":3,"channels":[{"name":"c"}]}]}`,
+		`{"id":1,"monitor_rules":null,"entities":null,"wallets":null,"monitor_tags":null}`,
+		`{"id":1,"monitor_rules":[{"id":3,"channels":[{"id":4,"params":null}]}]}`,
+		`{"id":1,"entities":[{"entity_type":1,"params":null}],"params":{"a":[1,{"b":null}]}}`,
+		`{"id":1,"monitor_rules":[{"id":3,"quiet_hours":{}}]}`,
+		`{"id":"1"}`,
+		`{}`,
+		`null`,
+		`[]`,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&MonitorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var monitor Monitor
+		decodeErr := json.Unmarshal(payload, &monitor)
+		checkErr := checkMonitorResponse(&monitor)
+
+		api := &payloadMonitorAPI{FakeMonitorAPI: NewFakeMonitorAPI(), payload: payload}
+		r := &MonitorResource{client: &Client{HexagateClient: api}}
+		_, found, diags := r.importedState(ctx, schemaResp.Schema, "1")
+
+		if decodeErr == nil && checkErr != nil && !diags.HasError() {
+			t.Fatalf("reading a monitor without IDs succeeded (%s): %s", checkErr, payload)
+		}
+		if decodeErr == nil && checkErr == nil && monitor.ID == 1 && !diags.HasError() && !found {
+			t.Fatalf("a complete monitor was reported as missing: %s", payload)
+		}
+	})
+}
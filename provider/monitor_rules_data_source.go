@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &MonitorRulesDataSource{}
+
+func NewMonitorRulesDataSource() datasource.DataSource {
+	return &MonitorRulesDataSource{}
+}
+
+type MonitorRulesDataSource struct {
+	client *Client
+}
+
+// MonitorRulesDataSourceModel describes the data source data model.
+type MonitorRulesDataSourceModel struct {
+	ID    types.String              `tfsdk:"id"`
+	Rules []MonitorRuleSummaryModel `tfsdk:"rules"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+// MonitorRuleSummaryModel describes a rule attached to a monitor, as reported by the API.
+type MonitorRuleSummaryModel struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	Threshold          types.Int64  `tfsdk:"threshold"`
+	NotificationPeriod types.Int64  `tfsdk:"notification_period"`
+	Categories         []int64      `tfsdk:"categories"`
+	ChannelIDs         []int64      `tfsdk:"channel_ids"`
+}
+
+func (d *MonitorRulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MonitorRulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_rules"
+}
+
+func (d *MonitorRulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the rules attached to a Hexagate monitor, including their server-assigned IDs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "Monitor identifier",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The rules attached to the monitor.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The server-assigned ID of the rule.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the rule.",
+						},
+						"threshold": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The threshold for the rule.",
+						},
+						"notification_period": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The notification period for the rule.",
+						},
+						"categories": schema.ListAttribute{
+							Computed:    true,
+							Description: "The categories for the rule.",
+							ElementType: types.Int64Type,
+						},
+						"channel_ids": schema.ListAttribute{
+							Computed:    true,
+							Description: "The IDs of the notification channels attached to the rule.",
+							ElementType: types.Int64Type,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *MonitorRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state MonitorRulesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Rules",
+			fmt.Sprintf("Could not parse ID: %s", err),
+		)
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitor, err := client.GetMonitor(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Rules",
+			fmt.Sprintf("Could not read monitor ID %d: %s", id, err),
+		)
+		return
+	}
+
+	state.Rules = make([]MonitorRuleSummaryModel, 0, len(monitor.MonitorRules))
+	for _, r := range monitor.MonitorRules {
+		rule := MonitorRuleSummaryModel{
+			ID:                 types.Int64Value(int64(r.ID)),
+			Name:               types.StringValue(r.Name),
+			Type:               types.StringValue("notification"),
+			Threshold:          types.Int64Value(int64(r.Threshold)),
+			NotificationPeriod: types.Int64Null(),
+			Categories:         make([]int64, 0, len(r.Categories)),
+			ChannelIDs:         make([]int64, 0, len(r.Channels)),
+		}
+		if r.Type != "" {
+			rule.Type = types.StringValue(r.Type)
+		}
+		if r.NotificationPeriod != nil {
+			rule.NotificationPeriod = types.Int64Value(int64(*r.NotificationPeriod))
+		}
+		for _, c := range r.Categories {
+			rule.Categories = append(rule.Categories, int64(c))
+		}
+		for _, ch := range r.Channels {
+			rule.ChannelIDs = append(rule.ChannelIDs, int64(ch.ID))
+		}
+
+		state.Rules = append(state.Rules, rule)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource              = &MonitorTestResource{}
+	_ resource.ResourceWithConfigure = &MonitorTestResource{}
+)
+
+func NewMonitorTestResource() resource.Resource {
+	return &MonitorTestResource{}
+}
+
+// MonitorTestResource sends a test notification through the channels of a
+// monitor's rules when it is created, so that misconfigured channels fail the
+// apply. It manages nothing in Hexagate.
+type MonitorTestResource struct {
+	client *Client
+}
+
+// MonitorTestResourceModel describes the resource data model.
+type MonitorTestResourceModel struct {
+	ID        types.String                  `tfsdk:"id"`
+	MonitorID types.String                  `tfsdk:"monitor_id"`
+	Rules     types.Set                     `tfsdk:"rules"`
+	Triggers  types.Map                     `tfsdk:"triggers"`
+	TestedAt  timetypes.RFC3339             `tfsdk:"tested_at"`
+	Results   []TestNotificationResultModel `tfsdk:"results"`
+}
+
+// TestNotificationResultModel describes the delivery of a test notification
+// to a channel.
+type TestNotificationResultModel struct {
+	Rule      types.String `tfsdk:"rule"`
+	ChannelID types.Int64  `tfsdk:"channel_id"`
+	Channel   types.String `tfsdk:"channel"`
+}
+
+func (r *MonitorTestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *MonitorTestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitor_test"
+}
+
+func (r *MonitorTestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends a test notification through the channels of a monitor's rules when created, and fails the apply " +
+			"if any channel can't be notified. Change triggers to send the test again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"monitor_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the monitor to test.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The names of the rules to test. Defaults to all rules of the monitor.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that send the test notification again when they change, " +
+					"e.g. the monitor's updated_at or a hash of its rules.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"tested_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Computed:    true,
+				Description: "When the test notification was sent.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The channels the test notification was delivered to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rule": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"channel_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The ID of the channel.",
+						},
+						"channel": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the channel.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *MonitorTestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var plan MonitorTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitorID, err := strconv.Atoi(plan.MonitorID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Testing Monitor",
+			fmt.Sprintf("Could not parse monitor ID: %s", err),
+		)
+		return
+	}
+
+	var test TestNotificationRequest
+	if !plan.Rules.IsNull() {
+		resp.Diagnostics.Append(plan.Rules.ElementsAs(ctx, &test.Rules, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	results, err := r.client.HexagateClient.SendTestNotification(ctx, monitorID, &test)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Testing Monitor",
+			fmt.Sprintf("Could not send a test notification for monitor ID %d: %s", monitorID, err),
+		)
+		return
+	}
+
+	var failed []string
+	for _, result := range results {
+		if !result.Delivered {
+			failed = append(failed, fmt.Sprintf("  - channel %q (ID %d) of rule %q: %s", result.Channel, result.ChannelID, result.Rule, result.Error))
+		}
+	}
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Test Notification Failed",
+			fmt.Sprintf("The test notification of monitor ID %d could not be delivered to:\n\n%s\n\n"+
+				"Fix the channels and apply again to repeat the test.", monitorID, strings.Join(failed, "\n")),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.Itoa(monitorID))
+	plan.TestedAt = timetypes.NewRFC3339TimeValue(time.Now().UTC().Truncate(time.Second))
+	plan.Results = make([]TestNotificationResultModel, len(results))
+	for i, result := range results {
+		plan.Results[i] = TestNotificationResultModel{
+			Rule:      types.StringValue(result.Rule),
+			ChannelID: types.Int64Value(int64(result.ChannelID)),
+			Channel:   types.StringValue(result.Channel),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the test from state when its monitor no longer exists, so that
+// the test is sent again once the monitor is recreated.
+func (r *MonitorTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state MonitorTestResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitorID, err := strconv.Atoi(state.MonitorID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Test",
+			fmt.Sprintf("Could not parse monitor ID: %s", err),
+		)
+		return
+	}
+
+	_, err = r.client.HexagateClient.GetMonitor(ctx, monitorID)
+	if isNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Monitor Test",
+			fmt.Sprintf("Could not read monitor ID %d: %s", monitorID, err),
+		)
+	}
+}
+
+// Update is never called with changes, since every argument requires
+// replacement.
+func (r *MonitorTestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan MonitorTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the test from state.
+func (r *MonitorTestResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
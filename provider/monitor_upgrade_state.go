@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ resource.ResourceWithUpgradeState = &MonitorResource{}
+
+// UpgradeState implements resource.ResourceWithUpgradeState.
+//
+// Version 0 kept the ID the API assigned to every channel in the channels
+// set. Version 1 only keeps the IDs of channels that refer to an existing
+// channel, and exports the others in channel_ids, so that the set elements
+// don't change when the API assigns IDs. Version 0 state can't tell the two
+// kinds of channels apart, so the upgrade keeps every ID; channels defined by
+// their name and params drop theirs on the next apply, since the
+// configuration doesn't set it, and keep their channel through channel_ids.
+func (r *MonitorResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := monitorSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				value, err := convertValue(req.State.Raw, resp.State.Schema.Type().TerraformType(ctx))
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Upgrading Monitor State",
+						fmt.Sprintf("Could not convert the state of the monitor to the current schema: %s", err),
+					)
+					return
+				}
+				resp.State.Raw = value
+			},
+		},
+	}
+}
+
+// convertValue converts a value of a prior schema to typ, the type of the
+// same attribute in the current schema. Attributes the prior schema doesn't
+// have are null, and attributes the current schema doesn't have are dropped.
+func convertValue(value tftypes.Value, typ tftypes.Type) (tftypes.Value, error) {
+	if value.IsNull() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	if !value.IsKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return tftypes.Value{}, err
+		}
+		converted := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attributeType := range typ.AttributeTypes {
+			attribute, ok := attributes[name]
+			if !ok {
+				converted[name] = tftypes.NewValue(attributeType, nil)
+				continue
+			}
+			v, err := convertValue(attribute, attributeType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			converted[name] = v
+		}
+		return tftypes.NewValue(typ, converted), nil
+	case tftypes.List:
+		elements, err := convertElements(value, typ.ElementType)
+		return tftypes.NewValue(typ, elements), err
+	case tftypes.Set:
+		elements, err := convertElements(value, typ.ElementType)
+		return tftypes.NewValue(typ, elements), err
+	case tftypes.Map:
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return tftypes.Value{}, err
+		}
+		for key, element := range elements {
+			v, err := convertValue(element, typ.ElementType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", key, err)
+			}
+			elements[key] = v
+		}
+		return tftypes.NewValue(typ, elements), nil
+	}
+
+	if !value.Type().Equal(typ) {
+		return tftypes.Value{}, fmt.Errorf("can't convert %s to %s", value.Type(), typ)
+	}
+	return value, nil
+}
+
+// convertElements converts the elements of a list or set to elementType.
+func convertElements(value tftypes.Value, elementType tftypes.Type) ([]tftypes.Value, error) {
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return nil, err
+	}
+	for i, element := range elements {
+		v, err := convertValue(element, elementType)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		elements[i] = v
+	}
+	return elements, nil
+}
+
+// monitorSchemaV0 is version 0 of the monitor schema, as far as needed to
+// decode state written with it. It must not change with the current schema.
+//
+// Categories were stored as numbers before they could be given by name; the
+// string type decodes those as well.
+func monitorSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":            schema.StringAttribute{Computed: true},
+			"name":          schema.StringAttribute{Required: true},
+			"monitor_id":    schema.Int64Attribute{Optional: true, Computed: true},
+			"clone_from_id": schema.Int64Attribute{Optional: true},
+			"description":   schema.StringAttribute{Optional: true},
+			"tags":          schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+			"entity_tags":   schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+			"wallets": schema.ListNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address":  schema.StringAttribute{Required: true},
+						"chain_id": schema.Int64Attribute{Required: true},
+					},
+				},
+			},
+			"disabled":                  schema.BoolAttribute{Optional: true, Computed: true},
+			"disable_on_destroy":        schema.BoolAttribute{Optional: true, Computed: true},
+			"deletion_protection":       schema.BoolAttribute{Optional: true, Computed: true},
+			"force_delete":              schema.BoolAttribute{Optional: true, Computed: true},
+			"params_merge_strategy":     schema.StringAttribute{Optional: true, Computed: true},
+			"ignore_params_array_order": schema.BoolAttribute{Optional: true, Computed: true},
+			"adopt_existing":            schema.BoolAttribute{Optional: true, Computed: true},
+			"params":                    schema.StringAttribute{CustomType: jsontypes.NormalizedType{}, Optional: true, Computed: true},
+			"created_by":                schema.StringAttribute{Computed: true},
+			"created_at":                schema.StringAttribute{CustomType: timetypes.RFC3339Type{}, Computed: true},
+			"updated_at":                schema.StringAttribute{CustomType: timetypes.RFC3339Type{}, Computed: true},
+			"rule_ids":                  schema.MapAttribute{Computed: true, ElementType: types.Int64Type},
+			"channel_ids":               schema.MapAttribute{Computed: true, ElementType: types.Int64Type},
+			"discovered_addresses":      schema.MapAttribute{Computed: true, ElementType: types.ListType{ElemType: types.StringType}},
+			"threshold_params": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"threshold": schema.Float64Attribute{Required: true},
+					"window":    schema.StringAttribute{Optional: true},
+				},
+			},
+			"address_params": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"addresses": schema.ListAttribute{Required: true, ElementType: types.StringType},
+				},
+			},
+			"token_params": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"tokens":     schema.ListAttribute{Required: true, ElementType: types.StringType},
+					"min_amount": schema.Float64Attribute{Optional: true},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"timeout":        schema.StringAttribute{Optional: true},
+					"max_retries":    schema.Int64Attribute{Optional: true},
+					"retry_wait_min": schema.StringAttribute{Optional: true},
+					"retry_wait_max": schema.StringAttribute{Optional: true},
+				},
+			},
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{Optional: true},
+					"read":   schema.StringAttribute{Optional: true},
+					"update": schema.StringAttribute{Optional: true},
+					"delete": schema.StringAttribute{Optional: true},
+				},
+			},
+			"entities": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.Int64Attribute{Required: true},
+						"params":      schema.StringAttribute{CustomType: jsontypes.NormalizedType{}, Optional: true},
+						"address":     schema.StringAttribute{Optional: true},
+						"chain_id":    schema.Int64Attribute{Optional: true},
+					},
+				},
+			},
+			"entities_for_chains": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.Int64Attribute{Required: true},
+						"address":     schema.StringAttribute{Required: true},
+						"chain_ids":   schema.SetAttribute{Required: true, ElementType: types.Int64Type},
+					},
+				},
+			},
+			"factory_entities": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type":     schema.Int64Attribute{Required: true},
+						"factory_address": schema.StringAttribute{Required: true},
+						"chain_id":        schema.Int64Attribute{Required: true},
+					},
+				},
+			},
+			"monitor_rules": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                   schema.Int64Attribute{Computed: true},
+						"key":                  schema.StringAttribute{Optional: true},
+						"name":                 schema.StringAttribute{Required: true},
+						"type":                 schema.StringAttribute{Required: true},
+						"threshold":            schema.Int64Attribute{Required: true},
+						"notification_period":  schema.Int64Attribute{Optional: true},
+						"cooldown_seconds":     schema.Int64Attribute{Optional: true},
+						"dedup_window_seconds": schema.Int64Attribute{Optional: true},
+						"quiet_hours": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"timezone":       schema.StringAttribute{Required: true},
+								"start":          schema.StringAttribute{Required: true},
+								"end":            schema.StringAttribute{Required: true},
+								"severity_floor": schema.Int64Attribute{Optional: true},
+							},
+						},
+						"categories": schema.ListAttribute{Required: true, ElementType: types.StringType},
+					},
+					Blocks: map[string]schema.Block{
+						"channels": schema.SetNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"id":     schema.Int64Attribute{Optional: true, Computed: true},
+									"name":   schema.StringAttribute{Optional: true, Computed: true},
+									"params": schema.StringAttribute{CustomType: jsontypes.NormalizedType{}, Optional: true, Computed: true, Sensitive: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// monitorStateV0 is the state of a monitor as version 0 of the schema wrote
+// it: with the ID of every channel in the channels set, and with categories
+// stored as numbers.
+const monitorStateV0 = `{
+	"id": "%d",
+	"name": "tf-acc-test-plan",
+	"monitor_id": 1,
+	"clone_from_id": null,
+	"description": "Plan test",
+	"tags": [],
+	"entity_tags": [],
+	"wallets": [],
+	"disabled": false,
+	"disable_on_destroy": false,
+	"deletion_protection": false,
+	"force_delete": false,
+	"params_merge_strategy": "subset",
+	"ignore_params_array_order": false,
+	"adopt_existing": false,
+	"params": null,
+	"created_by": "",
+	"created_at": "2024-05-01T12:00:00Z",
+	"updated_at": "2024-05-01T12:00:00Z",
+	"rule_ids": {"b": %d},
+	"channel_ids": {"b/b-channel": %d, "b/a-channel": %d},
+	"discovered_addresses": {},
+	"threshold_params": null,
+	"address_params": null,
+	"token_params": null,
+	"request_options": null,
+	"timeouts": null,
+	"entities": [{"entity_type": 1, "params": null, "address": "0x1f98431c8ad98523631ae4a59f267346ea31f984", "chain_id": 1}],
+	"entities_for_chains": [],
+	"factory_entities": [],
+	"monitor_rules": [{
+		"id": %d, "key": null, "name": "b", "type": "notification", "threshold": 50,
+		"notification_period": null, "cooldown_seconds": null, "dedup_window_seconds": null, "quiet_hours": null,
+		"categories": [1],
+		"channels": [
+			{"id": %d, "name": "b-channel", "params": "{\"url\":\"https://hooks.example.com/b\"}"},
+			{"id": %d, "name": "a-channel", "params": "{\"url\":\"https://hooks.example.com/a\"}"}
+		]
+	}]
+}`
+
+// TestMonitorUpgradeStateV0 checks that version 0 state upgrades without
+// losing the ID of a channel given by its ID only, and that applying the
+// configuration afterwards keeps the channels the monitor already has.
+func TestMonitorUpgradeStateV0(t *testing.T) {
+	api := provider.NewFakeMonitorAPI()
+	owner := newFakeTerraform(t, api, "hexagate_monitor")
+	owner.apply(planTestMonitor(planTestRule("a", 50)))
+	var ownerChannelIDs map[string]tftypes.Value
+	if err := owner.attribute("channel_ids").As(&ownerChannelIDs); err != nil {
+		t.Fatal(err)
+	}
+	sharedID := int64Value(t, ownerChannelIDs["a/a-channel"])
+
+	config := planTestMonitor(fmt.Sprintf(`{
+		"name": "b", "type": "notification", "threshold": 50, "categories": ["1"],
+		"channels": [{"name": "b-channel", "params": "{\"url\":\"https://hooks.example.com/b\"}"}, {"id": %d}]
+	}`, sharedID))
+	created := newFakeTerraform(t, api, "hexagate_monitor")
+	created.apply(config)
+	var channelIDs map[string]tftypes.Value
+	if err := created.attribute("channel_ids").As(&channelIDs); err != nil {
+		t.Fatal(err)
+	}
+	id := stringValue(t, created.attribute("id"))
+	ruleID := int64Value(t, created.attribute("monitor_rules", 0, "id"))
+	channelID := int64Value(t, channelIDs["b/b-channel"])
+
+	var monitorID int
+	if _, err := fmt.Sscan(id, &monitorID); err != nil {
+		t.Fatal(err)
+	}
+	state := fmt.Sprintf(monitorStateV0, monitorID, ruleID, channelID, sharedID, ruleID, channelID, sharedID)
+
+	tf := newFakeTerraform(t, api, "hexagate_monitor")
+	if err := tf.upgrade(0, state); err != nil {
+		t.Fatal(err)
+	}
+	if got := stringValue(t, tf.attribute("monitor_rules", 0, "categories", 0)); got != "1" {
+		t.Errorf("category = %q, want \"1\"", got)
+	}
+	var channels []tftypes.Value
+	if err := tf.attribute("monitor_rules", 0, "channels").As(&channels); err != nil {
+		t.Fatal(err)
+	}
+	var referenced bool
+	for _, channel := range channels {
+		if stringValue(t, valueAt(t, channel, "name")) == "a-channel" {
+			referenced = int64Value(t, valueAt(t, channel, "id")) == sharedID
+		}
+	}
+	if !referenced {
+		t.Errorf("channels = %v, want the referenced channel to keep its ID %d", channels, sharedID)
+	}
+
+	if plan := tf.apply(config); plan.replace {
+		t.Fatal("applying the configuration after the upgrade replaces the monitor")
+	}
+	if err := tf.attribute("channel_ids").As(&channelIDs); err != nil {
+		t.Fatal(err)
+	}
+	if got := int64Value(t, channelIDs["b/b-channel"]); got != channelID {
+		t.Errorf("ID of b-channel = %d, want %d kept", got, channelID)
+	}
+	if got := int64Value(t, channelIDs["b/a-channel"]); got != sharedID {
+		t.Errorf("ID of the referenced channel = %d, want %d", got, sharedID)
+	}
+}
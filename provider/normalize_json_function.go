@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/smartcontracts/terraform-provider-hexagate/internal/jsonutil"
+)
+
+var _ function.Function = &NormalizeJSONFunction{}
+
+func NewNormalizeJSONFunction() function.Function {
+	return &NormalizeJSONFunction{}
+}
+
+// NormalizeJSONFunction canonicalizes a JSON document, so that documents
+// that only differ in formatting or key order compare equal.
+type NormalizeJSONFunction struct{}
+
+func (f *NormalizeJSONFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_json"
+}
+
+func (f *NormalizeJSONFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Canonicalizes a JSON document",
+		Description: "Returns the JSON document with object keys sorted and without insignificant whitespace, " +
+			"so that documents built with jsonencode or templatefile compare equal when they only differ in formatting. " +
+			"Numbers are written in their shortest form, e.g. 1.50 and 1.5e0 as 1.5, and integers keep all their digits.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON document to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := jsonutil.Normalize(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid JSON: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &OpenAlertsDataSource{}
+
+func NewOpenAlertsDataSource() datasource.DataSource {
+	return &OpenAlertsDataSource{}
+}
+
+// OpenAlertsDataSource counts the open alerts of a selection of monitors, and
+// optionally fails the read when there are too many, to gate deployments on
+// them.
+type OpenAlertsDataSource struct {
+	client *Client
+}
+
+// OpenAlertsDataSourceModel describes the data source data model.
+type OpenAlertsDataSourceModel struct {
+	MonitorIDs     types.Set                `tfsdk:"monitor_ids"`
+	MonitorTags    types.Set                `tfsdk:"monitor_tags"`
+	MaxOpenAlerts  types.Int64              `tfsdk:"max_open_alerts"`
+	OpenAlertCount types.Int64              `tfsdk:"open_alert_count"`
+	Monitors       []OpenAlertsMonitorModel `tfsdk:"monitors"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+// OpenAlertsMonitorModel describes a monitor with open alerts.
+type OpenAlertsMonitorModel struct {
+	ID              types.String      `tfsdk:"id"`
+	Name            types.String      `tfsdk:"name"`
+	OpenAlertCount  types.Int64       `tfsdk:"open_alert_count"`
+	LastTriggeredAt timetypes.RFC3339 `tfsdk:"last_triggered_at"`
+}
+
+func (d *OpenAlertsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OpenAlertsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_open_alerts"
+}
+
+func (d *OpenAlertsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Counts the open alerts of the selected monitors. With max_open_alerts set, reading fails when there are more open alerts, which fails the plan.",
+		Attributes: map[string]schema.Attribute{
+			"monitor_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only include the monitors with these IDs.",
+			},
+			"monitor_tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only include the monitors that have all of these tags, e.g. [\"severity:critical\"].",
+			},
+			"max_open_alerts": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of open alerts. When there are more, reading the data source fails.",
+			},
+			"open_alert_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of open alerts of the selected monitors.",
+			},
+			"monitors": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The selected monitors with open alerts.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the monitor.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the monitor.",
+						},
+						"open_alert_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of open alerts of the monitor.",
+						},
+						"last_triggered_at": schema.StringAttribute{
+							CustomType:  timetypes.RFC3339Type{},
+							Computed:    true,
+							Description: "When the monitor last raised an alert.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *OpenAlertsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state OpenAlertsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids, tags []string
+	if !state.MonitorIDs.IsNull() {
+		resp.Diagnostics.Append(state.MonitorIDs.ElementsAs(ctx, &ids, false)...)
+	}
+	if !state.MonitorTags.IsNull() {
+		resp.Diagnostics.Append(state.MonitorTags.ElementsAs(ctx, &tags, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	monitors, err := client.GetAllMonitors(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Open Alerts",
+			fmt.Sprintf("Could not list monitors: %s", err),
+		)
+		return
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i].ID < monitors[j].ID })
+
+	var total int64
+	state.Monitors = []OpenAlertsMonitorModel{}
+	for _, monitor := range monitors {
+		id := strconv.Itoa(monitor.ID)
+		if (!state.MonitorIDs.IsNull() && !slices.Contains(ids, id)) || !hasAllTags(monitor.MonitorTags, tags) {
+			continue
+		}
+
+		summary, err := client.GetAlertSummary(ctx, monitor.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Open Alerts",
+				fmt.Sprintf("Could not read the alerts of monitor ID %d: %s", monitor.ID, err),
+			)
+			return
+		}
+		if summary.OpenCount == 0 {
+			continue
+		}
+
+		total += int64(summary.OpenCount)
+		state.Monitors = append(state.Monitors, OpenAlertsMonitorModel{
+			ID:              types.StringValue(id),
+			Name:            types.StringValue(monitor.Name),
+			OpenAlertCount:  types.Int64Value(int64(summary.OpenCount)),
+			LastTriggeredAt: timestampValue(summary.LastTriggeredAt),
+		})
+	}
+	state.OpenAlertCount = types.Int64Value(total)
+
+	if !state.MaxOpenAlerts.IsNull() && total > state.MaxOpenAlerts.ValueInt64() {
+		lines := make([]string, len(state.Monitors))
+		for i, monitor := range state.Monitors {
+			lines[i] = fmt.Sprintf("  - %s (ID %s): %d", monitor.Name.ValueString(), monitor.ID.ValueString(), monitor.OpenAlertCount.ValueInt64())
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_open_alerts"),
+			"Too Many Open Alerts",
+			fmt.Sprintf("The selected monitors have %d open alerts, more than the %d allowed by max_open_alerts:\n\n%s",
+				total, state.MaxOpenAlerts.ValueInt64(), strings.Join(lines, "\n")),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
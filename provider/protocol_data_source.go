@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ProtocolDataSource{}
+
+func NewProtocolDataSource() datasource.DataSource {
+	return &ProtocolDataSource{}
+}
+
+type ProtocolDataSource struct {
+	client *Client
+}
+
+// ProtocolDataSourceModel describes the data source data model.
+type ProtocolDataSourceModel struct {
+	ID        types.Int64             `tfsdk:"id"`
+	Name      types.String            `tfsdk:"name"`
+	ChainID   types.Int64             `tfsdk:"chain_id"`
+	Contracts []ProtocolContractModel `tfsdk:"contracts"`
+
+	RequestOptions *RequestOptionsModel `tfsdk:"request_options"`
+}
+
+// ProtocolContractModel describes a contract belonging to a protocol.
+type ProtocolContractModel struct {
+	Name    types.String `tfsdk:"name"`
+	Address types.String `tfsdk:"address"`
+	ChainID types.Int64  `tfsdk:"chain_id"`
+}
+
+func (d *ProtocolDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProtocolDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_protocol"
+}
+
+func (d *ProtocolDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a known protocol in Hexagate's registry by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the protocol.",
+			},
+			"chain_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return contracts deployed on this chain.",
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The ID of the protocol.",
+			},
+			"contracts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The contracts of the protocol.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the contract.",
+						},
+						"address": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address of the contract.",
+						},
+						"chain_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The chain the contract is deployed on.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request_options": requestOptionsDataSourceBlock(),
+		},
+	}
+}
+
+func (d *ProtocolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportRetries := trackRetries(ctx)
+	defer reportRetries(&resp.Diagnostics)
+
+	var state ProtocolDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.client.HexagateClient.WithRequestOptions(state.RequestOptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	protocols, err := client.SearchProtocols(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Protocol",
+			fmt.Sprintf("Could not search protocols: %s", err),
+		)
+		return
+	}
+
+	var protocol *Protocol
+	for _, p := range protocols {
+		if strings.EqualFold(p.Name, name) {
+			protocol = p
+			break
+		}
+	}
+	if protocol == nil {
+		resp.Diagnostics.AddError(
+			"Protocol Not Found",
+			fmt.Sprintf("No protocol named %q exists in the Hexagate registry.", name),
+		)
+		return
+	}
+
+	state.ID = types.Int64Value(int64(protocol.ID))
+	state.Contracts = make([]ProtocolContractModel, 0, len(protocol.Contracts))
+	for _, contract := range protocol.Contracts {
+		if !state.ChainID.IsNull() && int64(contract.ChainID) != state.ChainID.ValueInt64() {
+			continue
+		}
+		state.Contracts = append(state.Contracts, ProtocolContractModel{
+			Name:    types.StringValue(contract.Name),
+			Address: types.StringValue(contract.Address),
+			ChainID: types.Int64Value(int64(contract.ChainID)),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
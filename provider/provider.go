@@ -2,42 +2,159 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces
-var _ provider.Provider = &HexagateProvider{}
+var (
+	_ provider.Provider                       = &HexagateProvider{}
+	_ provider.ProviderWithFunctions          = &HexagateProvider{}
+	_ provider.ProviderWithEphemeralResources = &HexagateProvider{}
+)
+
+const (
+	defaultAPIRoot    = "https://api.hexagate.com/api"
+	defaultAPIVersion = "v2"
+
+	// monitorListCacheTTL bounds how stale a memoized monitor list may get
+	// within a single Terraform operation.
+	monitorListCacheTTL = 5 * time.Minute
+)
 
 // HexagateProvider is the provider implementation.
 type HexagateProvider struct {
 	// version is set to the provider version on release.
 	version string
+
+	// middlewares wrap the transport of the API client.
+	middlewares []Middleware
 }
 
 // ProviderClient wraps the HexagateClient with additional provider-specific data
 type Client struct {
-	HexagateClient *HexagateClient
+	HexagateClient MonitorAPI
 	UserAgent      string
+
+	// DefaultTags are merged into the tags of every monitor, formatted as "key:value".
+	DefaultTags []string
+
+	// BulkRead serves monitor refreshes from the memoized list of all monitors.
+	BulkRead bool
+
+	// PreventDestroy refuses to delete any resource unless AllowDestroyEnvVar is set.
+	PreventDestroy bool
+
+	// TemporaryTokens mints the tokens of hexagate_temporary_token. It is nil
+	// unless the provider authenticates with OAuth2 client credentials.
+	TemporaryTokens TemporaryTokenSource
+}
+
+// AllowDestroyEnvVar overrides the prevent_destroy_all provider attribute when set to a true value.
+const AllowDestroyEnvVar = "HEXAGATE_ALLOW_DESTROY"
+
+// CheckDestroyAllowed returns an error diagnostic if deleting resources is
+// forbidden by the prevent_destroy_all provider attribute.
+func (c *Client) CheckDestroyAllowed(resourceName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !c.PreventDestroy {
+		return diags
+	}
+	if allow, err := strconv.ParseBool(os.Getenv(AllowDestroyEnvVar)); err == nil && allow {
+		return diags
+	}
+
+	diags.AddError(
+		"Destroy Prevented",
+		fmt.Sprintf("Refusing to delete %s because the provider is configured with prevent_destroy_all = true. "+
+			"If this is intentional, set the %s environment variable to \"true\" and apply again, "+
+			"or remove the resource from state with \"terraform state rm\".", resourceName, AllowDestroyEnvVar),
+	)
+	return diags
 }
 
 // HexagateProviderModel describes the provider data model.
 type HexagateProviderModel struct {
-	APIToken types.String `tfsdk:"api_token"`
-	APIURL   types.String `tfsdk:"api_url"`
+	APIToken     types.String `tfsdk:"api_token"`
+	APIURL       types.String `tfsdk:"api_url"`
+	APIVersion   types.String `tfsdk:"api_version"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+	RetryJitter  types.Bool   `tfsdk:"retry_jitter"`
+	RetryBudget  types.String `tfsdk:"retry_budget"`
+
+	MaxIdleConns    types.Int64  `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost types.Int64  `tfsdk:"max_conns_per_host"`
+	IdleConnTimeout types.String `tfsdk:"idle_conn_timeout"`
+	KeepAlive       types.String `tfsdk:"keep_alive"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	MaxResponseSize       types.Int64 `tfsdk:"max_response_size"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	TLSMinVersion      types.String `tfsdk:"tls_min_version"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+
+	ExtraHeaders types.Map `tfsdk:"extra_headers"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Workspace      types.String `tfsdk:"workspace"`
+
+	APITokenFile    types.String `tfsdk:"api_token_file"`
+	APITokenCommand types.List   `tfsdk:"api_token_command"`
+
+	OAuthClientID     types.String `tfsdk:"oauth_client_id"`
+	OAuthClientSecret types.String `tfsdk:"oauth_client_secret"`
+	OAuthTokenURL     types.String `tfsdk:"oauth_token_url"`
+	OAuthScopes       types.List   `tfsdk:"oauth_scopes"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
+
+	DefaultTags types.Map `tfsdk:"default_tags"`
+
+	BulkRead types.Bool `tfsdk:"bulk_read"`
+
+	ReadOnly          types.Bool `tfsdk:"read_only"`
+	PreventDestroyAll types.Bool `tfsdk:"prevent_destroy_all"`
 }
 
-func New(version string) func() provider.Provider {
+func New(version string, opts ...Option) func() provider.Provider {
 	return func() provider.Provider {
-		return &HexagateProvider{
+		p := &HexagateProvider{
 			version: version,
 		}
+		for _, opt := range opts {
+			opt(p)
+		}
+		return p
 	}
 }
 
@@ -53,13 +170,154 @@ func (p *HexagateProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 		Description: "Interact with Hexagate.",
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The API token for Hexagate API authentication. Conflicts with api_token_file and api_token_command.",
+			},
+			"api_token_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file containing the API token. Conflicts with api_token and api_token_command.",
+			},
+			"api_token_command": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "A credential helper command, given as the program followed by its arguments, that prints the API token to stdout. Conflicts with api_token and api_token_file.",
+			},
+			"oauth_client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The OAuth2 client ID to authenticate with using the client credentials flow, as an alternative to an API token.",
+			},
+			"oauth_client_secret": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The API token for Hexagate API authentication.",
+				Description: "The OAuth2 client secret. Required when oauth_client_id is set.",
+			},
+			"oauth_token_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The OAuth2 token endpoint. Defaults to the oauth/token path of the API URL, or of the default API version when api_version is auto.",
+			},
+			"oauth_scopes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The OAuth2 scopes to request.",
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "A string appended to the User-Agent header of every API request, e.g. to attribute traffic to a pipeline.",
+			},
+			"default_tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags merged into the tags of every monitor managed by this provider, sent as \"key:value\".",
+			},
+			"bulk_read": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, monitors are refreshed from a single list of all monitors instead of one request per monitor. Defaults to false.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, any attempt to create, update or delete Hexagate resources fails. Useful for speculative plans with a shared token.",
+			},
+			"prevent_destroy_all": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, deleting any Hexagate resource fails unless the HEXAGATE_ALLOW_DESTROY environment variable is set to true.",
 			},
 			"api_url": schema.StringAttribute{
 				Optional:    true,
-				Description: "The URL for the Hexagate API.",
+				Description: "The URL for the Hexagate API. When api_version is set, this is the API root without the version path.",
+			},
+			"api_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Hexagate API version to use (e.g. \"v2\"), or \"auto\" to use the newest version supported by both the provider and the API.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a request is retried after a 429 or 5xx response. Defaults to 3.",
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Optional:    true,
+				Description: "The time to wait before the first retry, doubled for each further retry, as a Go duration string. With retry_jitter, the actual wait can be shorter. Defaults to \"1s\".",
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum time to wait between retries, as a Go duration string. A Retry-After header from the API can make the wait longer. Defaults to \"30s\".",
+			},
+			"retry_jitter": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to wait a random time between zero and the computed backoff between retries, so that concurrent requests don't retry in lockstep. Defaults to true.",
+			},
+			"retry_budget": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum total time spent on the API requests of a single resource or data source operation including their retries, as a Go duration string. Defaults to \"5m\". Set to \"0s\" to disable.",
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of idle connections kept open to the API. Must be at least 1. Defaults to 100.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of concurrent connections to the API. Defaults to 0, which means no limit.",
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long an idle connection is kept open, as a Go duration string. Defaults to \"90s\".",
+			},
+			"keep_alive": schema.StringAttribute{
+				Optional:    true,
+				Description: "The TCP keep-alive interval for connections to the API, as a Go duration string. Defaults to \"30s\".",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of API requests in flight at once, independent of Terraform's -parallelism. Defaults to 0, which means no limit.",
+			},
+			"max_response_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum size in bytes of a single decompressed API response. Defaults to 67108864 (64 MiB). Set to 0 to disable the limit.",
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of consecutive failed requests after which all further requests fail immediately. Defaults to 5. Set to 0 to disable.",
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long requests fail immediately once the circuit breaker opens, before a request is let through to probe the API, as a Go duration string. Defaults to \"1m\".",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates to trust in addition to the system roots.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verification of the API server's TLS certificate. Only use this for testing.",
+			},
+			"tls_min_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "The minimum TLS version to use, one of \"1.2\" or \"1.3\". Defaults to \"1.2\".",
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate for mutual TLS. Requires client_key_pem.",
+			},
+			"client_key_pem": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate for mutual TLS. Requires client_cert_pem.",
+			},
+			"extra_headers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional HTTP headers to send with every API request, including OAuth2 token requests, e.g. for API gateway authentication or tenant routing.",
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the Hexagate organization to scope all requests to.",
+			},
+			"workspace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Hexagate workspace to scope all requests to.",
 			},
 		},
 	}
@@ -75,34 +333,266 @@ func (p *HexagateProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	// Default values
-	apiURL := "https://api.hexagate.com/api/v2"
+	apiURL := defaultAPIRoot + "/" + defaultAPIVersion
 	if !config.APIURL.IsNull() {
 		apiURL = config.APIURL.ValueString()
 	}
 
-	if config.APIToken.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing API Token Configuration",
-			"While configuring the provider, the API token was not found. "+
-				"Please configure the api_token attribute in the provider configuration block.",
+	// With an explicit version, api_url is the root the version is appended to.
+	apiVersion := config.APIVersion.ValueString()
+	apiRoot := defaultAPIRoot
+	if apiVersion != "" {
+		if !config.APIURL.IsNull() {
+			apiRoot = strings.TrimSuffix(config.APIURL.ValueString(), "/")
+		}
+		apiURL = apiRoot + "/" + apiVersion
+		if apiVersion != "auto" && !slices.Contains(SupportedAPIVersions, apiVersion) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("api_version"),
+				"Unsupported API Version",
+				fmt.Sprintf("This version of the provider has not been tested against API version %q. Supported versions: %s.",
+					apiVersion, strings.Join(SupportedAPIVersions, ", ")),
+			)
+		}
+	}
+
+	maxRetries := 3
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Retry Configuration",
+			"The max_retries attribute must not be negative.",
+		)
+	}
+
+	retryWaitMin := parseDurationAttribute(config.RetryWaitMin, "retry_wait_min", time.Second, &resp.Diagnostics)
+	retryWaitMax := parseDurationAttribute(config.RetryWaitMax, "retry_wait_max", 30*time.Second, &resp.Diagnostics)
+
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Configuration",
+			"The retry_wait_min attribute must not be greater than retry_wait_max.",
+		)
+	}
+
+	retryJitter := true
+	if !config.RetryJitter.IsNull() {
+		retryJitter = config.RetryJitter.ValueBool()
+	}
+	retryBudget := parseDurationAttribute(config.RetryBudget, "retry_budget", 5*time.Minute, &resp.Diagnostics)
+
+	maxIdleConns := 100
+	if !config.MaxIdleConns.IsNull() {
+		maxIdleConns = int(config.MaxIdleConns.ValueInt64())
+	}
+	maxConnsPerHost := int(config.MaxConnsPerHost.ValueInt64())
+	if maxConnsPerHost < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_conns_per_host"),
+			"Invalid Connection Pool Configuration",
+			"The max_conns_per_host attribute must not be negative.",
 		)
+	}
+
+	var semaphore chan struct{}
+	if maxConcurrent := config.MaxConcurrentRequests.ValueInt64(); maxConcurrent > 0 {
+		semaphore = make(chan struct{}, maxConcurrent)
+	} else if maxConcurrent < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Connection Pool Configuration",
+			"The max_concurrent_requests attribute must not be negative.",
+		)
+	}
+
+	maxResponseSize := int64(defaultMaxResponseSize)
+	if !config.MaxResponseSize.IsNull() {
+		maxResponseSize = config.MaxResponseSize.ValueInt64()
+	}
+	if maxResponseSize < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_response_size"),
+			"Invalid Response Size Configuration",
+			"The max_response_size attribute must not be negative.",
+		)
+	}
+
+	circuitBreakerThreshold := 5
+	if !config.CircuitBreakerThreshold.IsNull() {
+		circuitBreakerThreshold = int(config.CircuitBreakerThreshold.ValueInt64())
+	}
+	if circuitBreakerThreshold < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Invalid Circuit Breaker Configuration",
+			"The circuit_breaker_threshold attribute must not be negative.",
+		)
+	}
+	circuitBreakerCooldown := parseDurationAttribute(config.CircuitBreakerCooldown, "circuit_breaker_cooldown", time.Minute, &resp.Diagnostics)
+
+	var circuitBreaker *CircuitBreaker
+	if circuitBreakerThreshold > 0 {
+		circuitBreaker = NewCircuitBreaker(circuitBreakerThreshold, circuitBreakerCooldown)
+	}
+
+	idleConnTimeout := parseDurationAttribute(config.IdleConnTimeout, "idle_conn_timeout", 90*time.Second, &resp.Diagnostics)
+	keepAlive := parseDurationAttribute(config.KeepAlive, "keep_alive", 30*time.Second, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	tlsConfig, diags := newTLSConfig(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	extraHeaders := make(map[string]string)
+	if !config.ExtraHeaders.IsNull() {
+		diags = config.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var defaultTags []string
+	if !config.DefaultTags.IsNull() {
+		tags := make(map[string]string)
+		diags = config.DefaultTags.ElementsAs(ctx, &tags, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for key, value := range tags {
+			defaultTags = append(defaultTags, fmt.Sprintf("%s:%s", key, value))
+		}
+		sort.Strings(defaultTags)
+	}
+
+	apiToken, diags := resolveAPIToken(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All requests go to a single host, so allow every idle connection to be
+	// kept for it instead of the default of two per host.
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: keepAlive,
+		}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConns,
+		MaxConnsPerHost:     maxConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+	}
+	roundTripper := wrapTransport(transport, p.middlewares)
+
 	// Create a custom User-Agent for API requests
 	userAgent := fmt.Sprintf("terraform-provider-hexagate/%s", p.version)
+	if suffix := config.UserAgentSuffix.ValueString(); suffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, suffix)
+	}
+
+	var tokenSource TokenSource
+	var temporaryTokens TemporaryTokenSource
+	if !config.OAuthClientID.IsNull() {
+		// The version is negotiated with requests that already need a
+		// token, so with api_version = "auto" the token endpoint of the
+		// default version is used.
+		tokenURL := fmt.Sprintf("%s/oauth/token", apiURL)
+		if apiVersion == "auto" {
+			tokenURL = fmt.Sprintf("%s/%s/oauth/token", apiRoot, defaultAPIVersion)
+		}
+		if !config.OAuthTokenURL.IsNull() {
+			tokenURL = config.OAuthTokenURL.ValueString()
+		}
+
+		var scopes []string
+		if !config.OAuthScopes.IsNull() {
+			diags = config.OAuthScopes.ElementsAs(ctx, &scopes, false)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		clientCredentials := &clientCredentialsTokenSource{
+			tokenURL:     tokenURL,
+			clientID:     config.OAuthClientID.ValueString(),
+			clientSecret: config.OAuthClientSecret.ValueString(),
+			scopes:       scopes,
+			client:       &http.Client{Transport: roundTripper},
+			userAgent:    userAgent,
+			headers:      extraHeaders,
+		}
+		tokenSource = clientCredentials
+		temporaryTokens = clientCredentials
+	}
+
+	hexagateClient := &HexagateClient{
+		APIToken:    apiToken,
+		TokenSource: tokenSource,
+		BaseURL:     apiURL,
+		Client:      &http.Client{Transport: roundTripper},
+		UserAgent:   userAgent,
+		ReadOnly:    config.ReadOnly.ValueBool(),
+		Semaphore:   semaphore,
+
+		CircuitBreaker:  circuitBreaker,
+		MaxResponseSize: maxResponseSize,
+		ETagCache:       NewETagCache(),
+
+		MonitorListCache: NewMonitorListCache(monitorListCacheTTL),
+
+		MaxRetries:   maxRetries,
+		RetryWaitMin: retryWaitMin,
+		RetryWaitMax: retryWaitMax,
+		RetryJitter:  retryJitter,
+		RetryBudget:  retryBudget,
+
+		OrganizationID: config.OrganizationID.ValueString(),
+		Workspace:      config.Workspace.ValueString(),
+
+		Headers: extraHeaders,
+	}
 
 	client := &Client{
-		HexagateClient: &HexagateClient{
-			APIToken: config.APIToken.ValueString(),
-			BaseURL:  apiURL,
-			Client:   &http.Client{},
-		},
-		UserAgent: userAgent,
+		HexagateClient: hexagateClient,
+		UserAgent:      userAgent,
+		DefaultTags:    defaultTags,
+
+		BulkRead:        config.BulkRead.ValueBool(),
+		PreventDestroy:  config.PreventDestroyAll.ValueBool(),
+		TemporaryTokens: temporaryTokens,
+	}
+
+	if apiVersion == "auto" {
+		version, err := hexagateClient.NegotiateAPIVersion(ctx, apiRoot)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_version"),
+				"Unable to Negotiate Hexagate API Version",
+				fmt.Sprintf("Failed to negotiate API version: %v", err),
+			)
+			return
+		}
+		hexagateClient.BaseURL = apiRoot + "/" + version
 	}
 
 	// Test the API connection
-	_, err := client.HexagateClient.GetAllMonitors()
+	_, err := client.HexagateClient.GetAllMonitors(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Connect to Hexagate API",
@@ -113,19 +603,131 @@ func (p *HexagateProvider) Configure(ctx context.Context, req provider.Configure
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// parseDurationAttribute parses a Go duration string provider attribute,
+// returning def when the attribute is null.
+func parseDurationAttribute(value types.String, name string, def time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() {
+		return def
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Duration",
+			fmt.Sprintf("The %s attribute %q is not a valid duration.", name, value.ValueString()),
+		)
+		return def
+	}
+
+	return d
+}
+
+// newTLSConfig builds the TLS configuration for the API client from the provider configuration.
+func newTLSConfig(config HexagateProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if !config.TLSMinVersion.IsNull() {
+		switch config.TLSMinVersion.ValueString() {
+		case "1.2":
+			tlsConfig.MinVersion = tls.VersionTLS12
+		case "1.3":
+			tlsConfig.MinVersion = tls.VersionTLS13
+		default:
+			diags.AddAttributeError(
+				path.Root("tls_min_version"),
+				"Invalid TLS Configuration",
+				fmt.Sprintf("The tls_min_version attribute must be \"1.2\" or \"1.3\", got: %q", config.TLSMinVersion.ValueString()),
+			)
+		}
+	}
+
+	if !config.CACertPEM.IsNull() {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.CACertPEM.ValueString())) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid TLS Configuration",
+				"The ca_cert_pem attribute does not contain any valid PEM encoded certificates.",
+			)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if !config.ClientCertPEM.IsNull() || !config.ClientKeyPEM.IsNull() {
+		if config.ClientCertPEM.IsNull() || config.ClientKeyPEM.IsNull() {
+			diags.AddError(
+				"Invalid TLS Configuration",
+				"The client_cert_pem and client_key_pem attributes must be configured together.",
+			)
+		} else {
+			cert, err := tls.X509KeyPair([]byte(config.ClientCertPEM.ValueString()), []byte(config.ClientKeyPEM.ValueString()))
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("client_cert_pem"),
+					"Invalid TLS Configuration",
+					fmt.Sprintf("Could not load the client certificate: %s", err),
+				)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, diags
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *HexagateProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewIntegrationsDataSource,
+		NewMonitorRulesDataSource,
+		NewAlertStatsDataSource,
+		NewProtocolDataSource,
+		NewOpenAlertsDataSource,
+		NewMonitorHealthDataSource,
+		NewContractCoveredDataSource,
 		// We'll implement these later
 		// NewMonitorDataSource,
 	}
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *HexagateProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTemporaryTokenEphemeralResource,
+		NewChannelParamsEphemeralResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *HexagateProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeJSONFunction,
+		NewIsValidAddressFunction,
+		NewChainIDFunction,
+		NewEntityParamsFunction,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *HexagateProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewMonitorResource,
+		NewMonitorTestResource,
+		NewMonitorEnablementResource,
 	}
 }
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RequestOptionsModel describes the request_options block, which overrides
+// the provider's request behavior for a single resource or data source.
+type RequestOptionsModel struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+}
+
+const requestOptionsDescription = "Overrides the provider's timeout and retry settings for the API requests made on behalf of this block's resource or data source."
+
+func requestOptionsResourceBlock() resourceschema.Block {
+	return resourceschema.SingleNestedBlock{
+		Description: requestOptionsDescription,
+		Attributes: map[string]resourceschema.Attribute{
+			"timeout": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The timeout of each API request attempt, as a Go duration string.",
+			},
+			"max_retries": resourceschema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a request is retried after a 429 or 5xx response.",
+			},
+			"retry_wait_min": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The minimum time to wait between retries, as a Go duration string.",
+			},
+			"retry_wait_max": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum time to wait between retries, as a Go duration string.",
+			},
+		},
+	}
+}
+
+func requestOptionsDataSourceBlock() datasourceschema.Block {
+	return datasourceschema.SingleNestedBlock{
+		Description: requestOptionsDescription,
+		Attributes: map[string]datasourceschema.Attribute{
+			"timeout": datasourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The timeout of each API request attempt, as a Go duration string.",
+			},
+			"max_retries": datasourceschema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a request is retried after a 429 or 5xx response.",
+			},
+			"retry_wait_min": datasourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The minimum time to wait between retries, as a Go duration string.",
+			},
+			"retry_wait_max": datasourceschema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum time to wait between retries, as a Go duration string.",
+			},
+		},
+	}
+}
+
+// WithRequestOptions returns a copy of the client with the given request
+// options applied. Unset options keep the client's values.
+func (c *HexagateClient) WithRequestOptions(opts *RequestOptionsModel) (MonitorAPI, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if opts == nil {
+		return c, diags
+	}
+
+	client := *c
+	optsPath := path.Root("request_options")
+
+	parseDuration := func(value types.String, name string, def time.Duration) time.Duration {
+		if value.IsNull() || value.IsUnknown() {
+			return def
+		}
+		d, err := time.ParseDuration(value.ValueString())
+		if err != nil || d < 0 {
+			diags.AddAttributeError(
+				optsPath.AtName(name),
+				"Invalid Request Options",
+				fmt.Sprintf("The %s attribute %q is not a valid duration.", name, value.ValueString()),
+			)
+			return def
+		}
+		return d
+	}
+
+	if !opts.Timeout.IsNull() && !opts.Timeout.IsUnknown() {
+		httpClient := *c.Client
+		httpClient.Timeout = parseDuration(opts.Timeout, "timeout", c.Client.Timeout)
+		client.Client = &httpClient
+	}
+
+	if !opts.MaxRetries.IsNull() && !opts.MaxRetries.IsUnknown() {
+		if opts.MaxRetries.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				optsPath.AtName("max_retries"),
+				"Invalid Request Options",
+				"The max_retries attribute must not be negative.",
+			)
+		}
+		client.MaxRetries = int(opts.MaxRetries.ValueInt64())
+	}
+
+	client.RetryWaitMin = parseDuration(opts.RetryWaitMin, "retry_wait_min", c.RetryWaitMin)
+	client.RetryWaitMax = parseDuration(opts.RetryWaitMax, "retry_wait_max", c.RetryWaitMax)
+	if client.RetryWaitMin > client.RetryWaitMax {
+		diags.AddAttributeError(
+			optsPath.AtName("retry_wait_min"),
+			"Invalid Request Options",
+			"The retry_wait_min attribute must not be greater than retry_wait_max.",
+		)
+	}
+
+	return &client, diags
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxResponseSize bounds the size of a single decompressed response
+// body unless the provider configures otherwise.
+const defaultMaxResponseSize = 64 << 20
+
+// ResponseTooLargeError is returned when a response body exceeds the client's
+// MaxResponseSize.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("the API response exceeds the maximum response size of %d bytes; raise max_response_size if this is expected", e.Limit)
+}
+
+// prepareResponse transparently decompresses gzip encoded bodies and limits
+// the body to maxSize bytes after decompression. A maxSize of zero disables
+// the limit.
+func prepareResponse(resp *http.Response, maxSize int64) (*http.Response, error) {
+	if maxSize > 0 && resp.ContentLength > maxSize && !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Body.Close()
+		return nil, &ResponseTooLargeError{Limit: maxSize}
+	}
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		reader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("decompressing response: %w", err)
+		}
+		resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	if maxSize > 0 {
+		resp.Body = &limitedBody{body: resp.Body, remaining: maxSize, limit: maxSize}
+	}
+
+	return resp, nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// limitedBody fails reads once more than limit bytes have been read, instead
+// of silently truncating the body like io.LimitReader.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, &ResponseTooLargeError{Limit: b.limit}
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), &ResponseTooLargeError{Limit: b.limit}
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type retryTrackerKey struct{}
+
+// retryTracker counts the retries made during a single resource or data
+// source operation, by reason.
+type retryTracker struct {
+	// started is when the operation started. The retry budget of all its
+	// requests runs from then.
+	started time.Time
+
+	mu      sync.Mutex
+	count   int
+	reasons map[string]int
+}
+
+// trackRetries returns a context that records the retries of the requests
+// sent with it, and a function that adds a warning summarizing them, if any.
+// The requests share a single retry budget.
+func trackRetries(ctx context.Context) (context.Context, func(*diag.Diagnostics)) {
+	tracker := &retryTracker{started: time.Now(), reasons: map[string]int{}}
+	ctx = context.WithValue(ctx, retryTrackerKey{}, tracker)
+
+	return ctx, func(diags *diag.Diagnostics) {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+
+		if tracker.count == 0 {
+			return
+		}
+
+		reasons := make([]string, 0, len(tracker.reasons))
+		for reason, n := range tracker.reasons {
+			reasons = append(reasons, fmt.Sprintf("%s (%dx)", reason, n))
+		}
+		sort.Strings(reasons)
+
+		diags.AddWarning(
+			"Hexagate API Requests Retried",
+			fmt.Sprintf("API requests were retried %d times during this operation: %s. "+
+				"Frequent retries slow down applies; if the API is rate limiting requests, consider lowering max_concurrent_requests.",
+				tracker.count, strings.Join(reasons, ", ")),
+		)
+	}
+}
+
+// recordRetry notes a retry of a request in the context's tracker, if any.
+func recordRetry(ctx context.Context, resp *http.Response, err error) {
+	tracker, ok := ctx.Value(retryTrackerKey{}).(*retryTracker)
+	if !ok {
+		return
+	}
+
+	reason := "request failed without a response"
+	if err == nil && resp != nil {
+		reason = resp.Status
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.count++
+	tracker.reasons[reason]++
+}
+
+// retryDeadline returns when a retry budget of budget runs out for requests
+// sent with the context: budget after the start of the context's operation,
+// or after now for a request sent outside an operation.
+func retryDeadline(ctx context.Context, budget time.Duration) time.Time {
+	if tracker, ok := ctx.Value(retryTrackerKey{}).(*retryTracker); ok {
+		return tracker.started.Add(budget)
+	}
+	return time.Now().Add(budget)
+}
@@ -0,0 +1,130 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+var (
+	sweep       = flag.Bool("sweep", false, "delete the monitors left behind by acceptance tests, instead of running the tests")
+	sweepDryRun = flag.Bool("sweep-dry-run", false, "with -sweep, list the monitors that would be deleted without deleting them")
+)
+
+// TestMain runs the sweeper instead of the tests when -sweep is set:
+//
+//	HEXAGATE_API_TOKEN=... go test ./provider -sweep
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !*sweep {
+		os.Exit(m.Run())
+	}
+
+	token := os.Getenv("HEXAGATE_API_TOKEN")
+	if token == "" {
+		log.Fatal("HEXAGATE_API_TOKEN must be set for the sweeper")
+	}
+	apiURL := os.Getenv("HEXAGATE_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.hexagate.com/api/v2"
+	}
+	client := &provider.HexagateClient{
+		APIToken:     token,
+		BaseURL:      apiURL,
+		Client:       &http.Client{Timeout: time.Minute},
+		UserAgent:    "terraform-provider-hexagate/test (sweeper)",
+		MaxRetries:   3,
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 30 * time.Second,
+	}
+
+	swept, err := sweepMonitors(context.Background(), client, *sweepDryRun)
+	for _, monitor := range swept {
+		if *sweepDryRun {
+			log.Printf("would delete monitor %q (ID %d)", monitor.Name, monitor.ID)
+		} else {
+			log.Printf("deleted monitor %q (ID %d)", monitor.Name, monitor.ID)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// sweepMonitors deletes the monitors whose name starts with accTestPrefix and
+// returns them, in ID order. With dryRun set, the monitors are returned
+// without being deleted. Channels are part of the rules of a monitor and are
+// deleted with it. Monitors that are already gone are not an error; the other
+// failed deletions are joined into the returned error and left out of the
+// result.
+func sweepMonitors(ctx context.Context, api provider.MonitorAPI, dryRun bool) ([]*provider.Monitor, error) {
+	monitors, err := api.GetAllMonitors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing monitors: %w", err)
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i].ID < monitors[j].ID })
+
+	var swept []*provider.Monitor
+	var errs []error
+	for _, monitor := range monitors {
+		if !strings.HasPrefix(monitor.Name, accTestPrefix) {
+			continue
+		}
+		if !dryRun {
+			err := api.DeleteMonitor(ctx, monitor.ID)
+			var apiErr *provider.APIError
+			if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+				errs = append(errs, fmt.Errorf("deleting monitor %q (ID %d): %w", monitor.Name, monitor.ID, err))
+				continue
+			}
+		}
+		swept = append(swept, monitor)
+	}
+	return swept, errors.Join(errs...)
+}
+
+func TestSweepMonitors(t *testing.T) {
+	ctx := context.Background()
+	api := provider.NewFakeMonitorAPI()
+	for _, name := range []string{accTestPrefix + "a", "production", accTestPrefix + "b", "tf-acc-other"} {
+		if _, err := api.CreateMonitor(ctx, &provider.Monitor{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(monitors []*provider.Monitor) []string {
+		var names []string
+		for _, monitor := range monitors {
+			names = append(names, monitor.Name)
+		}
+		return names
+	}
+	want := []string{accTestPrefix + "a", accTestPrefix + "b"}
+
+	swept, err := sweepMonitors(ctx, api, true)
+	if err != nil || strings.Join(names(swept), ",") != strings.Join(want, ",") {
+		t.Fatalf("dry run swept %v, %v, want %v", names(swept), err, want)
+	}
+	if monitors, _ := api.GetAllMonitors(ctx); len(monitors) != 4 {
+		t.Fatalf("dry run left %d monitors, want 4", len(monitors))
+	}
+
+	swept, err = sweepMonitors(ctx, api, false)
+	if err != nil || strings.Join(names(swept), ",") != strings.Join(want, ",") {
+		t.Fatalf("swept %v, %v, want %v", names(swept), err, want)
+	}
+	monitors, _ := api.GetAllMonitors(ctx)
+	if got := names(monitors); strings.Join(got, ",") != "production,tf-acc-other" {
+		t.Errorf("sweeping left %v, want the monitors without the prefix", got)
+	}
+}
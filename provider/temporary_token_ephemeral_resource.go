@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &TemporaryTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &TemporaryTokenEphemeralResource{}
+)
+
+func NewTemporaryTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TemporaryTokenEphemeralResource{}
+}
+
+// TemporaryTokenEphemeralResource mints a short-lived API token for the
+// duration of a Terraform run. The token is never written to state or plan.
+type TemporaryTokenEphemeralResource struct {
+	client *Client
+}
+
+// TemporaryTokenEphemeralResourceModel describes the ephemeral resource data
+// model.
+type TemporaryTokenEphemeralResourceModel struct {
+	Scopes    types.List        `tfsdk:"scopes"`
+	Token     types.String      `tfsdk:"token"`
+	ExpiresAt timetypes.RFC3339 `tfsdk:"expires_at"`
+}
+
+func (e *TemporaryTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
+
+func (e *TemporaryTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_token"
+}
+
+func (e *TemporaryTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mints a short-lived Hexagate API token for the duration of a Terraform run, for other providers or provisioners that need Hexagate access. " +
+			"The token is never written to state. Requires the provider to authenticate with OAuth2 client credentials.",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The OAuth2 scopes of the token. Defaults to the oauth_scopes of the provider.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token.",
+			},
+			"expires_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Computed:    true,
+				Description: "When the token expires. Null if the token endpoint doesn't report an expiry.",
+			},
+		},
+	}
+}
+
+func (e *TemporaryTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TemporaryTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if e.client.TemporaryTokens == nil {
+		resp.Diagnostics.AddError(
+			"Temporary Tokens Require OAuth2",
+			"Temporary tokens are minted with the OAuth2 client credentials of the provider. "+
+				"Configure oauth_client_id and oauth_client_secret in the provider to use hexagate_temporary_token.",
+		)
+		return
+	}
+
+	var scopes []string
+	if !data.Scopes.IsNull() {
+		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	token, expires, err := e.client.TemporaryTokens.MintToken(ctx, scopes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Minting Temporary Token",
+			fmt.Sprintf("Could not obtain a token from the OAuth2 token endpoint: %s", err),
+		)
+		return
+	}
+
+	data.Token = types.StringValue(token)
+	data.ExpiresAt = timetypes.NewRFC3339Null()
+	if !expires.IsZero() {
+		data.ExpiresAt = timetypes.NewRFC3339TimeValue(expires.UTC())
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
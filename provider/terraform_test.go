@@ -0,0 +1,778 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/smartcontracts/terraform-provider-hexagate/provider"
+)
+
+// testTerraform drives a single instance of a resource through the plugin
+// protocol the way Terraform does, so that the tests don't need a Terraform
+// binary. Configurations are given as JSON objects of the resource's
+// attributes and blocks. Like Terraform, it checks that plans are valid for
+// the configuration, that applies return what was planned, and that a
+// configuration plans no changes once applied.
+type testTerraform struct {
+	t        *testing.T
+	server   tfprotov6.ProviderServer
+	typeName string
+	schema   *tfprotov6.Schema
+
+	// state and private are the state of the resource instance, null when
+	// it doesn't exist.
+	state   tftypes.Value
+	private []byte
+}
+
+// testPlan is a planned change of the resource instance.
+type testPlan struct {
+	config  tftypes.Value
+	prior   tftypes.Value
+	planned tftypes.Value
+	private []byte
+	replace bool
+}
+
+// fakeClientProvider is the provider with its API client replaced by client,
+// e.g. to run the resources against a provider.FakeMonitorAPI.
+type fakeClientProvider struct {
+	fwprovider.Provider
+	client *provider.Client
+}
+
+func (p *fakeClientProvider) Configure(_ context.Context, _ fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	resp.DataSourceData = p.client
+	resp.ResourceData = p.client
+	resp.EphemeralResourceData = p.client
+}
+
+// newFakeTerraform returns a testTerraform for typeName whose resources send
+// their requests to api.
+func newFakeTerraform(t *testing.T, api provider.MonitorAPI, typeName string) *testTerraform {
+	t.Helper()
+	p := &fakeClientProvider{
+		Provider: provider.New("test")(),
+		client:   &provider.Client{HexagateClient: api, UserAgent: "terraform-provider-hexagate/test"},
+	}
+	return newTestTerraform(t, p, `{}`, typeName)
+}
+
+// newTestTerraform configures p with the JSON providerConfig and returns a
+// testTerraform for its resource type typeName.
+func newTestTerraform(t *testing.T, p fwprovider.Provider, providerConfig, typeName string) *testTerraform {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := diagnosticsError(t, schemaResp.Diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	schema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("the provider has no resource type %s", typeName)
+	}
+
+	config := decodeConfig(t, schemaResp.Provider, providerConfig)
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.9.0",
+		Config:           dynamicValue(t, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := diagnosticsError(t, configureResp.Diagnostics); err != nil {
+		t.Fatalf("configuring the provider: %s", err)
+	}
+
+	return &testTerraform{
+		t:        t,
+		server:   server,
+		typeName: typeName,
+		schema:   schema,
+		state:    tftypes.NewValue(schema.ValueType(), nil),
+	}
+}
+
+// apply refreshes the resource instance and applies config to it, like
+// terraform apply, then checks that config plans no changes. It returns the
+// applied plan.
+func (tf *testTerraform) apply(config string) testPlan {
+	tf.t.Helper()
+	plan, err := tf.tryApply(config)
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	tf.assertNoChanges(config)
+	return plan
+}
+
+// tryApply is apply without the final check, returning the errors of the
+// provider instead of failing the test.
+func (tf *testTerraform) tryApply(config string) (testPlan, error) {
+	tf.t.Helper()
+	plan, err := tf.plan(config)
+	if err != nil {
+		return plan, err
+	}
+
+	if plan.replace {
+		if err := tf.destroy(); err != nil {
+			return plan, err
+		}
+		if plan, err = tf.plan(config); err != nil {
+			return plan, err
+		}
+		plan.replace = true
+	}
+
+	return plan, tf.applyPlan(plan)
+}
+
+// plan refreshes the resource instance and plans config against it, like
+// terraform plan.
+func (tf *testTerraform) plan(config string) (testPlan, error) {
+	tf.t.Helper()
+	if err := tf.refresh(); err != nil {
+		return testPlan{}, err
+	}
+	return tf.planRefreshed(config)
+}
+
+// planRefreshed plans config against the state of the resource instance
+// without refreshing it first.
+func (tf *testTerraform) planRefreshed(config string) (testPlan, error) {
+	tf.t.Helper()
+	ctx := context.Background()
+	plan := testPlan{
+		config: decodeConfig(tf.t, tf.schema, config),
+		prior:  tf.state,
+	}
+
+	validateResp, err := tf.server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: tf.typeName,
+		Config:   dynamicValue(tf.t, plan.config),
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, validateResp.Diagnostics); err != nil {
+		return plan, fmt.Errorf("validating the configuration: %w", err)
+	}
+
+	proposed := proposedNew(tf.schema.Block, tf.state, plan.config)
+	resp, err := tf.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         tf.typeName,
+		PriorState:       dynamicValue(tf.t, tf.state),
+		ProposedNewState: dynamicValue(tf.t, proposed),
+		Config:           dynamicValue(tf.t, plan.config),
+		PriorPrivate:     tf.private,
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, resp.Diagnostics); err != nil {
+		return plan, fmt.Errorf("planning: %w", err)
+	}
+
+	plan.planned = decodeDynamicValue(tf.t, tf.schema, resp.PlannedState)
+	plan.private = resp.PlannedPrivate
+	plan.replace = !tf.state.IsNull() && len(resp.RequiresReplace) > 0
+	if problems := planProblems(tf.schema.Block, "", tf.state, plan.config, plan.planned); len(problems) > 0 {
+		tf.t.Fatalf("the provider produced an invalid plan:\n%s", strings.Join(problems, "\n"))
+	}
+	return plan, nil
+}
+
+// applyPlan applies plan, checking that the new state is the planned one.
+func (tf *testTerraform) applyPlan(plan testPlan) error {
+	tf.t.Helper()
+	resp, err := tf.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       tf.typeName,
+		PriorState:     dynamicValue(tf.t, plan.prior),
+		PlannedState:   dynamicValue(tf.t, plan.planned),
+		Config:         dynamicValue(tf.t, plan.config),
+		PlannedPrivate: plan.private,
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	newState := decodeDynamicValue(tf.t, tf.schema, resp.NewState)
+	if err := diagnosticsError(tf.t, resp.Diagnostics); err != nil {
+		// Like Terraform, keep whatever state the provider returned.
+		if resp.NewState != nil {
+			tf.state, tf.private = newState, resp.Private
+		}
+		return fmt.Errorf("applying: %w", err)
+	}
+
+	if problems := incompatibilities("", plan.planned, newState); len(problems) > 0 {
+		tf.t.Fatalf("the provider produced an inconsistent result after apply:\n%s", strings.Join(problems, "\n"))
+	}
+	tf.state, tf.private = newState, resp.Private
+	return nil
+}
+
+// refresh reads the resource instance, like terraform refresh.
+func (tf *testTerraform) refresh() error {
+	tf.t.Helper()
+	if tf.state.IsNull() {
+		return nil
+	}
+
+	resp, err := tf.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     tf.typeName,
+		CurrentState: dynamicValue(tf.t, tf.state),
+		Private:      tf.private,
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, resp.Diagnostics); err != nil {
+		return fmt.Errorf("refreshing: %w", err)
+	}
+	tf.state, tf.private = decodeDynamicValue(tf.t, tf.schema, resp.NewState), resp.Private
+	return nil
+}
+
+// importState imports the resource instance by id and refreshes it, like
+// terraform import.
+func (tf *testTerraform) importState(id string) error {
+	tf.t.Helper()
+	resp, err := tf.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: tf.typeName,
+		ID:       id,
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, resp.Diagnostics); err != nil {
+		return fmt.Errorf("importing: %w", err)
+	}
+	if len(resp.ImportedResources) != 1 {
+		tf.t.Fatalf("importing %s returned %d resources, want 1", id, len(resp.ImportedResources))
+	}
+
+	imported := resp.ImportedResources[0]
+	tf.state, tf.private = decodeDynamicValue(tf.t, tf.schema, imported.State), imported.Private
+	if err := tf.refresh(); err != nil {
+		tf.forget()
+		return err
+	}
+	if tf.state.IsNull() {
+		return fmt.Errorf("importing: %s doesn't exist", id)
+	}
+	return nil
+}
+
+// upgrade upgrades the JSON state of the resource instance written with
+// version of the schema and refreshes it, like Terraform does with state
+// written by an earlier version of the provider.
+func (tf *testTerraform) upgrade(version int64, state string) error {
+	tf.t.Helper()
+	resp, err := tf.server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: tf.typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, resp.Diagnostics); err != nil {
+		return fmt.Errorf("upgrading: %w", err)
+	}
+	tf.state = decodeDynamicValue(tf.t, tf.schema, resp.UpgradedState)
+	return tf.refresh()
+}
+
+// forget removes the resource instance from the state without destroying it,
+// like terraform state rm.
+func (tf *testTerraform) forget() {
+	tf.state = tftypes.NewValue(tf.schema.ValueType(), nil)
+	tf.private = nil
+}
+
+// destroy destroys the resource instance, like terraform destroy.
+func (tf *testTerraform) destroy() error {
+	tf.t.Helper()
+	ctx := context.Background()
+	null := tftypes.NewValue(tf.schema.ValueType(), nil)
+
+	planResp, err := tf.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         tf.typeName,
+		PriorState:       dynamicValue(tf.t, tf.state),
+		ProposedNewState: dynamicValue(tf.t, null),
+		Config:           dynamicValue(tf.t, null),
+		PriorPrivate:     tf.private,
+	})
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if err := diagnosticsError(tf.t, planResp.Diagnostics); err != nil {
+		return fmt.Errorf("planning the destroy: %w", err)
+	}
+
+	return tf.applyPlan(testPlan{
+		config:  null,
+		prior:   tf.state,
+		planned: null,
+		private: planResp.PlannedPrivate,
+	})
+}
+
+// assertNoChanges fails the test unless config plans no changes.
+func (tf *testTerraform) assertNoChanges(config string) {
+	tf.t.Helper()
+	plan, err := tf.plan(config)
+	if err != nil {
+		tf.t.Fatalf("planning after apply: %s", err)
+	}
+	if plan.replace || !plan.planned.Equal(tf.state) {
+		tf.t.Fatalf("the plan after apply isn't empty:\n%s", strings.Join(changes(tf.state, plan.planned), "\n"))
+	}
+}
+
+// attribute returns the value of the state at path, given as attribute names
+// list indexes and map keys, e.g. attribute("monitor_rules", 0, "id").
+func (tf *testTerraform) attribute(steps ...interface{}) tftypes.Value {
+	tf.t.Helper()
+	return valueAt(tf.t, tf.state, steps...)
+}
+
+func valueAt(t *testing.T, value tftypes.Value, steps ...interface{}) tftypes.Value {
+	t.Helper()
+	path := tftypes.NewAttributePath()
+	for _, step := range steps {
+		switch step := step.(type) {
+		case string:
+			path = path.WithAttributeName(step)
+		case int:
+			path = path.WithElementKeyInt(step)
+		case tftypes.ElementKeyString:
+			path = path.WithElementKeyString(string(step))
+		default:
+			t.Fatalf("invalid path step %v", step)
+		}
+	}
+
+	found, _, err := tftypes.WalkAttributePath(value, path)
+	if err != nil {
+		t.Fatalf("no value at %s: %s", path, err)
+	}
+	return found.(tftypes.Value)
+}
+
+// decodeConfig decodes a JSON configuration of schema. Nested list and set
+// blocks that aren't configured are empty, as in Terraform's configuration.
+func decodeConfig(t *testing.T, schema *tfprotov6.Schema, config string) tftypes.Value {
+	t.Helper()
+	value, err := tftypes.ValueFromJSON([]byte(config), schema.ValueType())
+	if err != nil {
+		t.Fatalf("decoding the configuration: %s", err)
+	}
+	return withEmptyBlocks(schema.Block, value)
+}
+
+func withEmptyBlocks(block *tfprotov6.SchemaBlock, value tftypes.Value) tftypes.Value {
+	if value.IsNull() || !value.IsKnown() {
+		return value
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		panic(err)
+	}
+	for _, nested := range block.BlockTypes {
+		blockValue := attributes[nested.TypeName]
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+			attributes[nested.TypeName] = withEmptyBlocks(nested.Block, blockValue)
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			elements := []tftypes.Value{}
+			if !blockValue.IsNull() {
+				if err := blockValue.As(&elements); err != nil {
+					panic(err)
+				}
+			}
+			for i, element := range elements {
+				elements[i] = withEmptyBlocks(nested.Block, element)
+			}
+			attributes[nested.TypeName] = tftypes.NewValue(blockValue.Type(), elements)
+		}
+	}
+	return tftypes.NewValue(value.Type(), attributes)
+}
+
+// proposedNew merges the configuration into the prior state like Terraform
+// does before planning: configured values are taken as they are, and computed
+// attributes that aren't configured keep their prior value. Nested blocks are
+// paired with their prior counterparts by index for lists, and by their
+// configured attributes for sets. Nested attributes are taken from the
+// configuration, since none of the provider's have computed attributes.
+func proposedNew(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	if config.IsNull() || !config.IsKnown() {
+		return config
+	}
+
+	priorAttributes := map[string]tftypes.Value{}
+	if !prior.IsNull() && prior.IsKnown() {
+		if err := prior.As(&priorAttributes); err != nil {
+			panic(err)
+		}
+	}
+	var configAttributes map[string]tftypes.Value
+	if err := config.As(&configAttributes); err != nil {
+		panic(err)
+	}
+	priorAttribute := func(name string) tftypes.Value {
+		if value, ok := priorAttributes[name]; ok {
+			return value
+		}
+		return tftypes.NewValue(configAttributes[name].Type(), nil)
+	}
+
+	attributes := map[string]tftypes.Value{}
+	for _, attribute := range block.Attributes {
+		value := configAttributes[attribute.Name]
+		if attribute.Computed && value.IsNull() {
+			value = priorAttribute(attribute.Name)
+		}
+		attributes[attribute.Name] = value
+	}
+
+	for _, nested := range block.BlockTypes {
+		configValue, priorValue := configAttributes[nested.TypeName], priorAttribute(nested.TypeName)
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+			attributes[nested.TypeName] = proposedNew(nested.Block, priorValue, configValue)
+			continue
+		}
+
+		var configElements, priorElements []tftypes.Value
+		if !configValue.IsNull() && configValue.IsKnown() {
+			if err := configValue.As(&configElements); err != nil {
+				panic(err)
+			}
+		}
+		if !priorValue.IsNull() && priorValue.IsKnown() {
+			if err := priorValue.As(&priorElements); err != nil {
+				panic(err)
+			}
+		}
+
+		elements := make([]tftypes.Value, len(configElements))
+		used := make([]bool, len(priorElements))
+		for i, configElement := range configElements {
+			priorElement := tftypes.NewValue(configElement.Type(), nil)
+			switch nested.Nesting {
+			case tfprotov6.SchemaNestedBlockNestingModeList:
+				if i < len(priorElements) {
+					priorElement = priorElements[i]
+				}
+			case tfprotov6.SchemaNestedBlockNestingModeSet:
+				for j := range priorElements {
+					if !used[j] && setElementMatches(nested.Block, priorElements[j], configElement) {
+						priorElement, used[j] = priorElements[j], true
+						break
+					}
+				}
+			}
+			elements[i] = proposedNew(nested.Block, priorElement, configElement)
+		}
+		if configValue.IsNull() || !configValue.IsKnown() {
+			attributes[nested.TypeName] = configValue
+		} else {
+			attributes[nested.TypeName] = tftypes.NewValue(configValue.Type(), elements)
+		}
+	}
+
+	return tftypes.NewValue(config.Type(), attributes)
+}
+
+// setElementMatches reports whether the prior element of a set block is the
+// one configured by config, i.e. whether they agree on every attribute that
+// is configured or not computed.
+func setElementMatches(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) bool {
+	var priorAttributes, configAttributes map[string]tftypes.Value
+	if prior.As(&priorAttributes) != nil || config.As(&configAttributes) != nil {
+		return false
+	}
+	for _, attribute := range block.Attributes {
+		configValue := configAttributes[attribute.Name]
+		if attribute.Computed && configValue.IsNull() {
+			continue
+		}
+		if !configValue.Equal(priorAttributes[attribute.Name]) {
+			return false
+		}
+	}
+	for _, nested := range block.BlockTypes {
+		if !configAttributes[nested.TypeName].Equal(priorAttributes[nested.TypeName]) {
+			return false
+		}
+	}
+	return true
+}
+
+// planProblems returns why planned is not a valid plan for config, following
+// Terraform's rules: attributes that aren't computed must be planned as
+// configured, and configured attributes may only be planned differently when
+// they keep their prior value. Set blocks are only checked for their count,
+// since their elements can't be correlated with the configuration.
+func planProblems(block *tfprotov6.SchemaBlock, path string, prior, config, planned tftypes.Value) []string {
+	if planned.IsNull() || !planned.IsKnown() || config.IsNull() {
+		return nil
+	}
+
+	attributes := func(value tftypes.Value) map[string]tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		if !value.IsNull() && value.IsKnown() {
+			if err := value.As(&attributes); err != nil {
+				panic(err)
+			}
+		}
+		return attributes
+	}
+	priorAttributes, configAttributes, plannedAttributes := attributes(prior), attributes(config), attributes(planned)
+	value := func(attributes map[string]tftypes.Value, name string, typ tftypes.Type) tftypes.Value {
+		if value, ok := attributes[name]; ok {
+			return value
+		}
+		return tftypes.NewValue(typ, nil)
+	}
+
+	var problems []string
+	for _, attribute := range block.Attributes {
+		name := path + attribute.Name
+		configValue := configAttributes[attribute.Name]
+		plannedValue := plannedAttributes[attribute.Name]
+		priorValue := value(priorAttributes, attribute.Name, configValue.Type())
+		switch {
+		case plannedValue.Equal(configValue):
+		case !priorValue.IsNull() && !configValue.IsNull() && plannedValue.Equal(priorValue):
+		case attribute.Computed && !attribute.Optional:
+		case attribute.Computed && configValue.IsNull():
+		default:
+			problems = append(problems, fmt.Sprintf("%s: planned %s, but configured %s", name, plannedValue, configValue))
+		}
+	}
+
+	for _, nested := range block.BlockTypes {
+		name := path + nested.TypeName
+		configValue := configAttributes[nested.TypeName]
+		plannedValue := plannedAttributes[nested.TypeName]
+		priorValue := value(priorAttributes, nested.TypeName, configValue.Type())
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+			problems = append(problems, planProblems(nested.Block, name+".", priorValue, configValue, plannedValue)...)
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			if !plannedValue.IsKnown() {
+				continue
+			}
+			var configElements, plannedElements, priorElements []tftypes.Value
+			_ = configValue.As(&configElements)
+			_ = plannedValue.As(&plannedElements)
+			if !priorValue.IsNull() && priorValue.IsKnown() {
+				_ = priorValue.As(&priorElements)
+			}
+			if len(plannedElements) != len(configElements) {
+				problems = append(problems, fmt.Sprintf("%s: planned %d blocks, but configured %d", name, len(plannedElements), len(configElements)))
+				continue
+			}
+			if nested.Nesting == tfprotov6.SchemaNestedBlockNestingModeSet {
+				continue
+			}
+			for i := range plannedElements {
+				priorElement := tftypes.NewValue(configElements[i].Type(), nil)
+				if i < len(priorElements) {
+					priorElement = priorElements[i]
+				}
+				problems = append(problems, planProblems(nested.Block, fmt.Sprintf("%s[%d].", name, i), priorElement, configElements[i], plannedElements[i])...)
+			}
+		}
+	}
+	return problems
+}
+
+// incompatibilities returns the known values of planned that actual doesn't
+// have, which Terraform reports as an inconsistent result after apply.
+func incompatibilities(path string, planned, actual tftypes.Value) []string {
+	switch {
+	case !planned.IsKnown():
+		return nil
+	case !actual.IsKnown():
+		return []string{fmt.Sprintf("%s: planned %s, but it is unknown", path, planned)}
+	case planned.IsNull() || actual.IsNull():
+		if planned.IsNull() != actual.IsNull() {
+			return []string{fmt.Sprintf("%s: planned %s, but got %s", path, planned, actual)}
+		}
+		return nil
+	}
+
+	switch planned.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var plannedValues, actualValues map[string]tftypes.Value
+		_ = planned.As(&plannedValues)
+		_ = actual.As(&actualValues)
+		names := make([]string, 0, len(plannedValues))
+		for name := range plannedValues {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var problems []string
+		if len(plannedValues) != len(actualValues) {
+			problems = append(problems, fmt.Sprintf("%s: planned %d elements, but got %d", path, len(plannedValues), len(actualValues)))
+		}
+		for _, name := range names {
+			actualValue, ok := actualValues[name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: planned %s, but it is missing", path, name, plannedValues[name]))
+				continue
+			}
+			problems = append(problems, incompatibilities(path+"."+name, plannedValues[name], actualValue)...)
+		}
+		return problems
+
+	case tftypes.List, tftypes.Tuple:
+		var plannedElements, actualElements []tftypes.Value
+		_ = planned.As(&plannedElements)
+		_ = actual.As(&actualElements)
+		if len(plannedElements) != len(actualElements) {
+			return []string{fmt.Sprintf("%s: planned %d elements, but got %d", path, len(plannedElements), len(actualElements))}
+		}
+		var problems []string
+		for i := range plannedElements {
+			problems = append(problems, incompatibilities(fmt.Sprintf("%s[%d]", path, i), plannedElements[i], actualElements[i])...)
+		}
+		return problems
+
+	case tftypes.Set:
+		if planned.IsFullyKnown() {
+			if !planned.Equal(actual) {
+				return []string{fmt.Sprintf("%s: planned %s, but got %s", path, planned, actual)}
+			}
+			return nil
+		}
+		var plannedElements, actualElements []tftypes.Value
+		_ = planned.As(&plannedElements)
+		_ = actual.As(&actualElements)
+		var problems []string
+		for _, plannedElement := range plannedElements {
+			found := false
+			for _, actualElement := range actualElements {
+				if len(incompatibilities(path, plannedElement, actualElement)) == 0 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				problems = append(problems, fmt.Sprintf("%s: planned element %s is missing from %s", path, plannedElement, actual))
+			}
+		}
+		return problems
+	}
+
+	if !planned.Equal(actual) {
+		return []string{fmt.Sprintf("%s: planned %s, but got %s", path, planned, actual)}
+	}
+	return nil
+}
+
+// changes describes the differences between the prior and planned values.
+func changes(prior, planned tftypes.Value) []string {
+	diffs, err := prior.Diff(planned)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	describe := func(value *tftypes.Value) string {
+		if value == nil {
+			return "(absent)"
+		}
+		return value.String()
+	}
+	var changes []string
+	for _, diff := range diffs {
+		changes = append(changes, fmt.Sprintf("%s: %s => %s", diff.Path, describe(diff.Value1), describe(diff.Value2)))
+	}
+	return changes
+}
+
+func dynamicValue(t *testing.T, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	dynamic, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dynamic
+}
+
+func decodeDynamicValue(t *testing.T, schema *tfprotov6.Schema, dynamic *tfprotov6.DynamicValue) tftypes.Value {
+	t.Helper()
+	if dynamic == nil {
+		return tftypes.NewValue(schema.ValueType(), nil)
+	}
+	value, err := dynamic.Unmarshal(schema.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+// diagnosticsError returns the error diagnostics as an error, and logs the
+// warnings.
+func diagnosticsError(t *testing.T, diagnostics []*tfprotov6.Diagnostic) error {
+	t.Helper()
+	var errs []error
+	for _, d := range diagnostics {
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		if d.Attribute != nil {
+			message = d.Attribute.String() + ": " + message
+		}
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, errors.New(message))
+		} else {
+			t.Logf("warning: %s", message)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func int64Value(t *testing.T, value tftypes.Value) int64 {
+	t.Helper()
+	var number big.Float
+	if err := value.As(&number); err != nil {
+		t.Fatal(err)
+	}
+	i, _ := number.Int64()
+	return i
+}
+
+func stringValue(t *testing.T, value tftypes.Value) string {
+	t.Helper()
+	var s string
+	if err := value.As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
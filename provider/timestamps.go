@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+)
+
+// timestampLayouts are the formats the API is known to return timestamps in.
+// Timestamps without a time zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseTimestamp parses a timestamp returned by the API.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp format: %q", value)
+}
+
+// timestampValue converts a timestamp returned by the API into an RFC 3339
+// attribute value in UTC, so that changes to the API's formatting don't show
+// as drift. Missing and unparseable timestamps are null.
+func timestampValue(value string) timetypes.RFC3339 {
+	t, err := parseTimestamp(value)
+	if err != nil {
+		return timetypes.NewRFC3339Null()
+	}
+	return timetypes.NewRFC3339TimeValue(t.UTC())
+}
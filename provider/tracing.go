@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"regexp"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/smartcontracts/terraform-provider-hexagate/provider"
+
+// numericPathSegment matches IDs in request paths, which are replaced in span
+// names to keep their cardinality low.
+var numericPathSegment = regexp.MustCompile(`/[0-9]+(/|$)`)
+
+// SetupTracing exports a span for every API request over OTLP/HTTP when the
+// standard OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// environment variable is set. The returned function flushes pending spans and
+// must be called before the provider exits. Without an endpoint, tracing is
+// disabled and the returned function does nothing.
+func SetupTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("terraform-provider-hexagate"),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// startRequestSpan starts a span for a single attempt of an API request.
+func startRequestSpan(req *http.Request, attempt int) (context.Context, trace.Span) {
+	route := numericPathSegment.ReplaceAllString(req.URL.Path, "/{id}$1")
+	return otel.Tracer(tracerName).Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.HTTPRoute(route),
+			attribute.Int("http.request.resend_count", attempt),
+			attribute.String("hexagate.request_id", req.Header.Get("X-Request-Id")),
+		),
+	)
+}
+
+// endRequestSpan records the outcome of an API request attempt and ends its span.
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the time zone database, so that time zones are validated the same
+	// regardless of the zoneinfo installed where Terraform runs.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/sha3"
+)
+
+var _ validator.String = jsonObjectValidator{}
+
+// jsonObjectValidator checks that a string attribute holds a JSON object,
+// as opposed to any other JSON value such as an array or a string.
+type jsonObjectValidator struct{}
+
+func jsonObject() validator.String {
+	return jsonObjectValidator{}
+}
+
+func (v jsonObjectValidator) Description(_ context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// Invalid JSON is reported by the jsontypes.Normalized attribute type.
+	var value interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &value); err != nil {
+		return
+	}
+
+	if _, ok := value.(map[string]interface{}); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			fmt.Sprintf("The %s attribute must be a JSON object, e.g. jsonencode({ key = \"value\" }), got %s.", req.Path, jsonKind(value)),
+		)
+	}
+}
+
+var _ validator.String = categoryValidator{}
+
+// categoryValidator checks that a string attribute holds a category ID
+// between 1 and 7 or a category name. Names are checked against the API when
+// the monitor is written.
+type categoryValidator struct{}
+
+func categoryValue() validator.String {
+	return categoryValidator{}
+}
+
+func (v categoryValidator) Description(_ context.Context) string {
+	return "value must be a category ID between 1 and 7 or a category name"
+}
+
+func (v categoryValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v categoryValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	id, err := strconv.Atoi(value)
+	if (err == nil && (id < 1 || id > 7)) || strings.TrimSpace(value) == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Category",
+			fmt.Sprintf("The %s attribute must be a category ID between 1 and 7 or a category name, got: %q.", req.Path, value),
+		)
+	}
+}
+
+var _ validator.String = addressValidator{}
+
+// timeOfDayPattern matches a time of day as "HH:MM".
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// addressPattern matches a hex encoded 20-byte address.
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// addressValidator checks that a string attribute holds an address. Addresses
+// in mixed case must have a valid EIP-55 checksum, so that a mistyped
+// character is caught before the monitor silently watches the wrong address.
+type addressValidator struct{}
+
+func addressValue() validator.String {
+	return addressValidator{}
+}
+
+func (v addressValidator) Description(_ context.Context) string {
+	return "value must be a 0x-prefixed 20-byte hex address, with a valid EIP-55 checksum if in mixed case"
+}
+
+func (v addressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v addressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !addressPattern.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Address",
+			fmt.Sprintf("The %s attribute must be a 0x-prefixed address of 40 hex characters, got: %q.", req.Path, value),
+		)
+		return
+	}
+
+	if !isValidAddress(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Address Checksum",
+			fmt.Sprintf("The %s attribute %q does not match its EIP-55 checksum. Check the address for typos; the checksummed form is %q.", req.Path, value, checksumAddress(value)),
+		)
+	}
+}
+
+// checksumAddress returns the EIP-55 mixed case form of a hex address. A hex
+// digit is upper case when the corresponding nibble of the Keccak-256 hash of
+// the lower case address is 8 or greater.
+func checksumAddress(address string) string {
+	digits := strings.ToLower(strings.TrimPrefix(address, "0x"))
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(digits))
+	hashed := hex.EncodeToString(hash.Sum(nil))
+
+	checksummed := []byte(digits)
+	for i, c := range checksummed {
+		if c >= 'a' && c <= 'f' && hashed[i] >= '8' {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
+
+var _ validator.String = timezoneValidator{}
+
+// timezoneValidator checks that a string attribute holds an IANA time zone
+// name, such as "Europe/London".
+type timezoneValidator struct{}
+
+func timezoneValue() validator.String {
+	return timezoneValidator{}
+}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name, e.g. \"Europe/London\""
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("The %s attribute must be an IANA time zone name, e.g. \"Europe/London\" or \"UTC\", got: %q.", req.Path, value),
+		)
+	}
+}
+
+var _ resource.ConfigValidator = monitorTargetsValidator{}
+
+// monitorTargetAttributes are the attributes that select what a monitor
+// watches, at least one of which has to be configured.
+var monitorTargetAttributes = []string{"entities", "entities_for_chains", "factory_entities", "wallets", "entity_tags"}
+
+// monitorTargetsValidator checks that a monitor watches something, so that a
+// monitor without targets fails validation instead of being rejected by the
+// API.
+type monitorTargetsValidator struct{}
+
+func monitorTargets() resource.ConfigValidator {
+	return monitorTargetsValidator{}
+}
+
+func (v monitorTargetsValidator) Description(_ context.Context) string {
+	return "at least one of " + strings.Join(monitorTargetAttributes, ", ") + " must be configured"
+}
+
+func (v monitorTargetsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v monitorTargetsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, name := range monitorTargetAttributes {
+		var value interface {
+			IsNull() bool
+			IsUnknown() bool
+			Elements() []attr.Value
+		}
+		if name == "wallets" {
+			var list types.List
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &list)...)
+			value = list
+		} else {
+			var set types.Set
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &set)...)
+			value = set
+		}
+		if resp.Diagnostics.HasError() || value.IsUnknown() || (!value.IsNull() && len(value.Elements()) > 0) {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("entities"),
+		"Missing Monitor Entities",
+		"A monitor must watch at least one entity. Configure at least one of "+strings.Join(monitorTargetAttributes, ", ")+".",
+	)
+}
+
+// jsonKind describes the kind of a decoded JSON value.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "an object"
+	}
+}